@@ -0,0 +1,89 @@
+package cloudhealth
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// ErrNotFound is matched by errors.Is for every error reporting a missing resource, such as ErrAwsAccountNotFound,
+// ErrPerspectiveNotFound or an APIError with a 404 Not Found status.
+var ErrNotFound = errors.New("Not found")
+
+// notFoundError is the type of the sentinel errors of missing resources, each matching ErrNotFound.
+type notFoundError string
+
+// Error implements error.
+func (e notFoundError) Error() string {
+	return string(e)
+}
+
+// Is reports whether target is ErrNotFound.
+func (e notFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// maxAPIErrorBody is the most bytes of an error response kept in an APIError.
+const maxAPIErrorBody = 64 << 10
+
+// APIError is returned for a response status other than those of a successful call. Err, when set, is the
+// error the status means for the call, such as ErrAwsAccountNotFound or ErrClientAuthenticationError, and is
+// matched by errors.Is. Body often holds CloudHealth's explanation, such as field-level validation messages.
+type APIError struct {
+	StatusCode int
+	Body       []byte // up to 64 KiB of the response body
+	Method     string
+	URL        string // without the api_key query parameter
+	RequestID  string
+	Err        error
+}
+
+// Error implements error.
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("Unknown Response with CloudHealth: `%d` for %s %s (request ID `%s`)", e.StatusCode, e.Method, e.URL, e.RequestID)
+	if e.Err != nil {
+		msg = fmt.Sprintf("%s (`%d` for %s %s, request ID `%s`)", e.Err, e.StatusCode, e.Method, e.URL, e.RequestID)
+	}
+	if len(e.Body) > 0 {
+		msg += ": " + string(e.Body)
+	}
+	return msg
+}
+
+// Unwrap returns Err, or ErrNotFound for a 404 Not Found status without one.
+func (e *APIError) Unwrap() error {
+	if e.Err == nil && e.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	return e.Err
+}
+
+// newAPIError returns the APIError for resp wrapping err, reading the response body.
+func newAPIError(resp *http.Response, err error) *APIError {
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxAPIErrorBody))
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       body,
+		RequestID:  requestID(resp),
+		Err:        err,
+	}
+	if req := resp.Request; req != nil {
+		e.Method = req.Method
+		e.URL = redactedURL(req.URL)
+	}
+	return e
+}
+
+// redactedURL returns u without the api_key query parameter, for errors and logs.
+func redactedURL(u *url.URL) string {
+	redacted := *u
+	q := redacted.Query()
+	if q.Has("api_key") {
+		q.Del("api_key")
+		redacted.RawQuery = q.Encode()
+	}
+	return redacted.String()
+}
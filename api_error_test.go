@@ -0,0 +1,82 @@
+package cloudhealth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAPIError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"name is too long"}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("secretApiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.AuthMode = AuthAPIKey
+
+	_, err = c.UpdateAwsAccount(AwsAccount{ID: 1, Name: "test", Authentication: NewAssumeRoleAuth("arn:aws:iam::123456789012:role/CloudHealth", "externalid")})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Errorf("UpdateAwsAccount() expected an *APIError, got %v", err)
+		return
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || string(apiErr.Body) != `{"error":"name is too long"}` ||
+		apiErr.Method != "PUT" || apiErr.URL != ts.URL+"/aws_accounts/1" {
+		t.Errorf("UpdateAwsAccount() returned an unexpected APIError: %#v", apiErr)
+	}
+	if strings.Contains(err.Error(), "secretApiKey") {
+		t.Errorf("Expected the error not to contain the API key: %s", err)
+	}
+}
+
+func TestAPIErrorPerspective(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	_, err = c.CreatePerspective(&defaultPerspective)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("CreatePerspective() expected an *APIError for the 500 response, got %v", err)
+	}
+}
+
+func TestErrNotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	_, err = c.GetAwsAccount(1)
+	var apiErr *APIError
+	if !errors.Is(err, ErrNotFound) || !errors.Is(err, ErrAwsAccountNotFound) || !errors.As(err, &apiErr) {
+		t.Errorf("GetAwsAccount() expected an APIError wrapping ErrAwsAccountNotFound, got %v", err)
+	}
+	if _, err := c.GetAwsExternalID(); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetAwsExternalID() expected ErrNotFound, got %v", err)
+	}
+	if !errors.Is(ErrPerspectiveNotFound, ErrNotFound) || !errors.Is(ErrGroupNotFound, ErrNotFound) {
+		t.Errorf("Expected the not found sentinels to match ErrNotFound")
+	}
+}
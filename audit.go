@@ -0,0 +1,122 @@
+package cloudhealth
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditEntry records a mutating (POST, PUT or DELETE) request made to CloudHealth.
+// The ResourceID of a successful POST is the identifier of the created resource.
+type AuditEntry struct {
+	Time         time.Time `json:"time"`
+	Method       string    `json:"method"`
+	ResourceType string    `json:"resource_type"`
+	ResourceID   string    `json:"resource_id,omitempty"`
+	StatusCode   int       `json:"status_code,omitempty"`
+	Success      bool      `json:"success"`
+	Error        string    `json:"error,omitempty"`
+	RequestID    string    `json:"request_id,omitempty"`
+}
+
+// AuditSink receives an AuditEntry for every mutating request made by a Client.
+type AuditSink interface {
+	Record(entry AuditEntry)
+}
+
+// AuditSinkFunc adapts a function to an AuditSink.
+type AuditSinkFunc func(entry AuditEntry)
+
+// Record implements AuditSink.
+func (f AuditSinkFunc) Record(entry AuditEntry) {
+	f(entry)
+}
+
+// JSONAuditSink writes audit entries as JSON lines. It is safe for concurrent use.
+type JSONAuditSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONAuditSink returns a JSONAuditSink writing to w.
+func NewJSONAuditSink(w io.Writer) *JSONAuditSink {
+	return &JSONAuditSink{enc: json.NewEncoder(w)}
+}
+
+// Record implements AuditSink.
+func (s *JSONAuditSink) Record(entry AuditEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.enc.Encode(entry)
+}
+
+// auditTransport records mutating requests to an AuditSink.
+type auditTransport struct {
+	next     http.RoundTripper
+	sink     AuditSink
+	basePath string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != "POST" && req.Method != "PUT" && req.Method != "DELETE" {
+		return t.next.RoundTrip(req)
+	}
+
+	entry := AuditEntry{
+		Time:      time.Now(),
+		Method:    req.Method,
+		RequestID: req.Header.Get(RequestIDHeader),
+	}
+	entry.ResourceType, entry.ResourceID = t.resource(req)
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.StatusCode = resp.StatusCode
+		entry.Success = resp.StatusCode >= 200 && resp.StatusCode < 300
+		if entry.Success && req.Method == "POST" {
+			var body []byte
+			if body, err = ioutil.ReadAll(resp.Body); err != nil {
+				entry.Error = err.Error()
+				entry.Success = false
+			}
+			resp.Body.Close()
+			resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+			entry.ResourceID = createdID(body)
+		}
+	}
+	t.sink.Record(entry)
+	return resp, err
+}
+
+// resource returns the resource type and identifier addressed by req, e.g. "aws_accounts" and "1234".
+func (t *auditTransport) resource(req *http.Request) (string, string) {
+	p := strings.TrimPrefix(req.URL.Path, t.basePath)
+	parts := strings.SplitN(strings.Trim(p, "/"), "/", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// createdID returns the identifier of the resource created by a POST responding with body: its "id" field,
+// or the ID in the message CloudHealth responds with to Perspective creations. It is empty when not found.
+func createdID(body []byte) string {
+	var created struct {
+		ID json.Number `json:"id"`
+	}
+	if err := json.Unmarshal(body, &created); err == nil && created.ID != "" {
+		return created.ID.String()
+	}
+	if match := perspectiveCreatedPattern.FindSubmatch(body); match != nil {
+		return string(match[1])
+	}
+	return ""
+}
@@ -0,0 +1,109 @@
+package cloudhealth
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuditSinkRecordsMutations(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "DELETE":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+			body, _ := json.Marshal(defaultAWSAccount)
+			w.Write(body)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL+"/v1/"))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	var entries []AuditEntry
+	c.AuditSink = AuditSinkFunc(func(entry AuditEntry) {
+		entries = append(entries, entry)
+	})
+
+	c.GetAwsAccount(defaultAWSAccount.ID)
+	c.UpdateAwsAccount(defaultAWSAccount)
+	c.DeleteAwsAccount(defaultAWSAccount.ID)
+
+	if len(entries) != 2 {
+		t.Errorf("Expected 2 audit entries, got %d: %+v", len(entries), entries)
+		return
+	}
+	id := fmt.Sprintf("%d", defaultAWSAccount.ID)
+	if e := entries[0]; e.Method != "PUT" || e.ResourceType != "aws_accounts" || e.ResourceID != id || !e.Success {
+		t.Errorf("Unexpected audit entry: %+v", e)
+	}
+	if e := entries[1]; e.Method != "DELETE" || e.StatusCode != http.StatusNotFound || e.Success || e.RequestID == "" {
+		t.Errorf("Unexpected audit entry: %+v", e)
+	}
+}
+
+func TestAuditSinkRecordsCreatedIDs(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		if r.URL.Path == "/aws_accounts" {
+			w.Write([]byte(`{"id":4321,"name":"test"}`))
+			return
+		}
+		w.Write([]byte(`{"message":"Perspective 1234567890 created"}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	var entries []AuditEntry
+	c.AuditSink = AuditSinkFunc(func(entry AuditEntry) {
+		entries = append(entries, entry)
+	})
+
+	account, err := c.CreateAwsAccount(AwsAccount{Name: "test"})
+	if err != nil || account.ID != 4321 {
+		t.Errorf("CreateAwsAccount() expected the created account after auditing, got %v, %v", account, err)
+		return
+	}
+	if _, err := c.CreatePerspective(&Perspective{Schema: Schema{Name: "test", IncludeInReports: "true"}}); err != nil {
+		t.Errorf("CreatePerspective() returned an error: %s", err)
+		return
+	}
+
+	if len(entries) != 2 {
+		t.Errorf("Expected 2 audit entries, got %d: %+v", len(entries), entries)
+		return
+	}
+	if e := entries[0]; e.Method != "POST" || e.ResourceType != "aws_accounts" || e.ResourceID != "4321" {
+		t.Errorf("Unexpected audit entry: %+v", e)
+	}
+	if e := entries[1]; e.ResourceType != "perspective_schemas" || e.ResourceID != "1234567890" {
+		t.Errorf("Unexpected audit entry: %+v", e)
+	}
+}
+
+func TestJSONAuditSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewJSONAuditSink(&buf)
+	sink.Record(AuditEntry{Method: "DELETE", ResourceType: "perspective_schemas", ResourceID: "1234", Success: true})
+	sink.Record(AuditEntry{Method: "POST", ResourceType: "aws_accounts", Error: "timeout"})
+
+	dec := json.NewDecoder(&buf)
+	for i := 0; i < 2; i++ {
+		var entry AuditEntry
+		if err := dec.Decode(&entry); err != nil {
+			t.Errorf("Unable to decode audit entry %d: %s", i, err)
+			return
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package cloudhealth
+
+import "net/http"
+
+// AuthMode selects how requests are authenticated with CloudHealth.
+type AuthMode int
+
+const (
+	// AuthBearer sends the key as an OAuth-style bearer token in the Authorization header. This is the default,
+	// keeping the key out of URLs and so out of proxy logs, access logs and error messages.
+	AuthBearer AuthMode = iota
+	// AuthAPIKey sends the key as the api_key query parameter, for compatibility with gateways expecting it there.
+	AuthAPIKey
+)
+
+// authenticate adds the credentials to req according to the Client's AuthMode.
+func (s *Client) authenticate(req *http.Request, apiKey string) {
+	switch s.AuthMode {
+	case AuthAPIKey:
+		q := req.URL.Query()
+		q.Set("api_key", apiKey)
+		req.URL.RawQuery = q.Encode()
+	default:
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+}
@@ -0,0 +1,60 @@
+package cloudhealth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAuthAPIKey(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "api_key=apiKey&page=2" {
+			t.Errorf("Expected query ‘api_key=apiKey&page=2’, got ‘%s’", r.URL.RawQuery)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("Expected no Authorization header, got ‘%s’", auth)
+		}
+		w.Write([]byte(`{"aws_accounts":[]}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.AuthMode = AuthAPIKey
+
+	if _, err := do[AwsAccounts](c, apiCall{method: "GET", path: "aws_accounts", query: map[string][]string{"page": {"2"}}}); err != nil {
+		t.Errorf("do() returned an error: %s", err)
+	}
+}
+
+func TestAuthBearerDefault(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer token" {
+			t.Errorf("Expected Authorization header ‘Bearer token’, got ‘%s’", auth)
+		}
+		if r.URL.Query().Has("api_key") {
+			t.Errorf("Expected no api_key query parameter, got ‘%s’", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"generated_external_id":"externalid"}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("token", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if _, err := c.GetAwsExternalID(); err != nil {
+		t.Errorf("GetAwsExternalID() returned an error: %s", err)
+	}
+}
+
+// bearerToken returns the token of the Authorization header of r.
+func bearerToken(r *http.Request) string {
+	return strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+}
@@ -1,15 +1,11 @@
 package cloudhealth
 
 import (
-	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"net/http"
-	"net/url"
 	"strconv"
-	"time"
 )
 
 // AwsAccount represents the configuration of an AWS Account enabled in CloudHealth.
@@ -17,6 +13,11 @@ type AwsAccount struct {
 	ID             int                      `json:"id"`
 	Name           string                   `json:"name"`
 	Authentication AwsAccountAuthentication `json:"authentication"`
+	OwnerID        string                   `json:"owner_id,omitempty"`     // the 12 digit AWS account ID
+	AccountType    string                   `json:"account_type,omitempty"` // read-only, e.g. "Standalone", "Consolidated" or "Linked"
+	Billing        *AwsAccountBilling       `json:"billing,omitempty"`
+	CloudTrail     *AwsAccountCloudTrail    `json:"cloudtrail,omitempty"`
+	Status         *AwsAccountStatus        `json:"status,omitempty"` // read-only, reported by CloudHealth
 }
 
 // AwsAccounts is a structure to unmarshal CloudHealth GET accounts results into
@@ -28,235 +29,152 @@ type AwsAccounts struct {
 type AwsAccountAuthentication struct {
 	Protocol             string `json:"protocol"`
 	AccessKey            string `json:"access_key,omitempty"`
-	SecreyKey            string `json:"secret_key,omitempty"`
+	SecretKey            string `json:"secret_key,omitempty"`
 	AssumeRoleArn        string `json:"assume_role_arn,omitempty"`
 	AssumeRoleExternalID string `json:"assume_role_external_id,omitempty"`
-}
-
-// ErrAwsAccountNotFound is returned when an AWS Account doesn't exist on a Read or Delete.
-// It's useful for ignoring errors (e.g. delete if exists).
-var ErrAwsAccountNotFound = errors.New("AWS Account not found")
-
-// getPaginatedAwsAccounts retrieves a page of results for the GetAllAwsAccounts function
-func getPaginatedAwsAccounts(client *http.Client, req *http.Request, page, perPage int) (*AwsAccounts, error) {
-	var accountsPage = new(AwsAccounts)
 
-	q := req.URL.Query()
-	q.Set("per_page", strconv.Itoa(perPage))
-	q.Set("page", strconv.Itoa(page))
-	req.URL.RawQuery = q.Encode()
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	// Deprecated: SecreyKey is a misspelling kept for compatibility, use SecretKey instead.
+	// It is sent as the secret key when SecretKey is empty and is populated alongside SecretKey when decoding.
+	SecreyKey string `json:"-"`
+}
 
-	responseBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+// MarshalJSON implements json.Marshaler, falling back to the deprecated SecreyKey when SecretKey is empty.
+func (a AwsAccountAuthentication) MarshalJSON() ([]byte, error) {
+	type authentication AwsAccountAuthentication
+	out := authentication(a)
+	if out.SecretKey == "" {
+		out.SecretKey = a.SecreyKey
 	}
+	return json.Marshal(out)
+}
 
-	switch resp.StatusCode {
-	case http.StatusOK:
-		err = json.Unmarshal(responseBody, &accountsPage)
-		if err != nil {
-			return nil, err
-		}
-		return accountsPage, nil
-	case http.StatusUnauthorized:
-		return nil, ErrClientAuthenticationError
-	case http.StatusNotFound:
-		return nil, ErrAwsAccountNotFound
-	default:
-		return nil, fmt.Errorf("Unknown Response from CloudHealth: `%d`", resp.StatusCode)
+// UnmarshalJSON implements json.Unmarshaler, populating both SecretKey and the deprecated SecreyKey.
+func (a *AwsAccountAuthentication) UnmarshalJSON(data []byte) error {
+	type authentication AwsAccountAuthentication
+	if err := json.Unmarshal(data, (*authentication)(a)); err != nil {
+		return err
 	}
+	a.SecreyKey = a.SecretKey
+	return nil
 }
 
-// GetAllAwsAccounts gets all AWS Accounts
-func (s *Client) GetAllAwsAccounts(perPage int) ([]AwsAccount, error) {
-	var accounts []AwsAccount
+// AwsAccountBilling configures the S3 bucket CloudHealth reads the billing reports of a payer account from.
+// The aws_accounts payload holds no processing status of the reports, which is only reflected in the overall
+// Status of the AWS Account.
+type AwsAccountBilling struct {
+	Bucket string `json:"bucket,omitempty"`
+}
 
-	// Establish our HTTP client
-	relativeURL, _ := url.Parse(fmt.Sprintf("aws_accounts?api_key=%s", s.ApiKey))
-	apiUrl := s.EndpointURL.ResolveReference(relativeURL)
-	req, err := http.NewRequest("GET", apiUrl.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-	client := &http.Client{
-		Timeout: time.Second * time.Duration(s.Timeout),
-	}
+// AwsAccountCloudTrail configures where CloudHealth collects the CloudTrail logs of an AWS Account.
+type AwsAccountCloudTrail struct {
+	Enabled bool   `json:"enabled"`
+	Bucket  string `json:"bucket,omitempty"`
+	Prefix  string `json:"prefix,omitempty"`
+}
 
-	// Get Paginated results for AWS accounts endpoint
-	// CloudHealth starts counting pages at 1 (but also accepts 0 which has results identical to 1)
-	for pageNo, pageLen := 1, perPage; pageLen == perPage; pageNo++ {
-		accountsPage, err := getPaginatedAwsAccounts(client, req, pageNo, perPage)
-		if err != nil {
-			return nil, err
-		}
-		accounts = append(accounts, accountsPage.Accounts...)
-		pageLen = len(accountsPage.Accounts)
-	}
-	if err != nil {
-		return nil, err
-	}
-	return accounts, nil
+// AwsAccountStatus represents the health of an AWS Account integration as reported by CloudHealth.
+type AwsAccountStatus struct {
+	Level      string `json:"level"`
+	LastUpdate string `json:"last_update,omitempty"`
 }
 
-// GetAwsAccount gets the AWS Account with the specified CloudHealth Account ID.
-func (s *Client) GetAwsAccount(id int) (*AwsAccount, error) {
+// ErrAwsAccountNotFound is returned when an AWS Account doesn't exist on a Read or Delete.
+// It's useful for ignoring errors (e.g. delete if exists).
+var ErrAwsAccountNotFound error = notFoundError("AWS Account not found")
 
-	relativeURL, _ := url.Parse(fmt.Sprintf("aws_accounts/%d?api_key=%s", id, s.ApiKey))
-	url := s.EndpointURL.ResolveReference(relativeURL)
+var awsAccounts = resource[AwsAccount]{path: "aws_accounts", notFound: ErrAwsAccountNotFound, schema: awsAccountPayloadSchema}
 
-	req, err := http.NewRequest("GET", url.String(), nil)
+// AwsAccountsPageFunc is called with each page of AWS Accounts retrieved by GetAwsAccountsPages.
+// Returning false stops the iteration after the current page.
+type AwsAccountsPageFunc func(page []AwsAccount) bool
 
-	client := &http.Client{
-		Timeout: time.Second * time.Duration(s.Timeout),
+// GetAllAwsAccounts gets all AWS Accounts. When the listing is interrupted by the context given WithContext,
+// the accounts retrieved so far are returned along with a *ListInterruptedError.
+func (s *Client) GetAllAwsAccounts(perPage int, opts ...CallOption) (accounts []AwsAccount, err error) {
+	defer annotate(&err, "GetAllAwsAccounts", "")
+
+	err = s.awsAccountsPages(perPage, func(page []AwsAccount) bool {
+		accounts = append(accounts, page...)
+		return true
+	}, opts)
+	var interrupted *ListInterruptedError
+	if errors.As(err, &interrupted) {
+		return accounts, err
 	}
-	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	return accounts, nil
+}
 
-	responseBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
+// GetAwsAccountsPages iterates over the AWS Accounts one page at a time, calling fn for each page.
+// This allows callers to process large tenants incrementally instead of holding every account in memory.
+func (s *Client) GetAwsAccountsPages(perPage int, fn AwsAccountsPageFunc, opts ...CallOption) (err error) {
+	defer annotate(&err, "GetAwsAccountsPages", "")
+	return s.awsAccountsPages(perPage, fn, opts)
+}
 
-	switch resp.StatusCode {
-	case http.StatusOK:
-		var account = new(AwsAccount)
-		err = json.Unmarshal(responseBody, &account)
-		if err != nil {
-			return nil, err
-		}
+func (s *Client) awsAccountsPages(perPage int, fn AwsAccountsPageFunc, opts []CallOption) error {
+	return listPages(s, awsAccounts, perPage, func(page *AwsAccounts) []AwsAccount {
+		return page.Accounts
+	}, fn, opts)
+}
 
-		return account, nil
-	case http.StatusUnauthorized:
-		return nil, ErrClientAuthenticationError
-	case http.StatusNotFound:
-		return nil, ErrAwsAccountNotFound
-	default:
-		return nil, fmt.Errorf("Unknown Response with CloudHealth: `%d`", resp.StatusCode)
-	}
+// GetAwsAccount gets the AWS Account with the specified CloudHealth Account ID.
+func (s *Client) GetAwsAccount(id int, opts ...CallOption) (account *AwsAccount, err error) {
+	defer annotate(&err, "GetAwsAccount", id)
+	return awsAccounts.get(s, strconv.Itoa(id), opts)
 }
 
 // CreateAwsAccount enables a new AWS Account in CloudHealth.
-func (s *Client) CreateAwsAccount(account AwsAccount) (*AwsAccount, error) {
-
-	body, _ := json.Marshal(account)
-
-	relativeURL, _ := url.Parse(fmt.Sprintf("aws_accounts?api_key=%s", s.ApiKey))
-	url := s.EndpointURL.ResolveReference(relativeURL)
-
-	req, err := http.NewRequest("POST", url.String(), bytes.NewBuffer(body))
-	req.Header.Add("Content-Type", "application/json")
-
-	client := &http.Client{
-		Timeout: time.Second * time.Duration(s.Timeout),
-	}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	responseBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
+func (s *Client) CreateAwsAccount(account AwsAccount, opts ...CallOption) (created *AwsAccount, err error) {
+	defer annotate(&err, "CreateAwsAccount", account.Name)
+	if err := account.Validate(); err != nil {
 		return nil, err
 	}
+	return idempotentCreate(s, "CreateAwsAccount", opts, func() (*AwsAccount, error) {
+		return s.findAwsAccount(account, opts)
+	}, func() (*AwsAccount, error) {
+		return awsAccounts.create(s, account, awsAccountNameConflict(account), opts)
+	})
+}
 
-	switch resp.StatusCode {
-	case http.StatusCreated:
-		var account = new(AwsAccount)
-		err = json.Unmarshal(responseBody, &account)
-		if err != nil {
-			return nil, err
+// findAwsAccount returns the AWS Account with the OwnerID of account, or its Name when it has no OwnerID, or nil.
+func (s *Client) findAwsAccount(account AwsAccount, opts []CallOption) (*AwsAccount, error) {
+	var found *AwsAccount
+	err := s.awsAccountsPages(100, func(page []AwsAccount) bool {
+		for i, candidate := range page {
+			if (account.OwnerID != "" && candidate.OwnerID == account.OwnerID) || (account.OwnerID == "" && candidate.Name == account.Name) {
+				found = &page[i]
+				return false
+			}
 		}
-
-		return account, nil
-	case http.StatusUnauthorized:
-		return nil, ErrClientAuthenticationError
-	case http.StatusUnprocessableEntity:
-		return nil, fmt.Errorf("Bad Request. Please check if a AWS Account with this name `%s` already exists", account.Name)
-	default:
-		return nil, fmt.Errorf("Unknown Response with CloudHealth: `%d`", resp.StatusCode)
-	}
+		return true
+	}, opts)
+	return found, err
 }
 
 // UpdateAwsAccount updates an existing AWS Account in CloudHealth.
-func (s *Client) UpdateAwsAccount(account AwsAccount) (*AwsAccount, error) {
-
-	relativeURL, _ := url.Parse(fmt.Sprintf("aws_accounts/%d?api_key=%s", account.ID, s.ApiKey))
-	url := s.EndpointURL.ResolveReference(relativeURL)
-
-	body, _ := json.Marshal(account)
-
-	req, err := http.NewRequest("PUT", url.String(), bytes.NewBuffer((body)))
-	req.Header.Add("Content-Type", "application/json")
-
-	client := &http.Client{
-		Timeout: time.Second * time.Duration(s.Timeout),
-	}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	responseBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
+func (s *Client) UpdateAwsAccount(account AwsAccount, opts ...CallOption) (updated *AwsAccount, err error) {
+	defer annotate(&err, "UpdateAwsAccount", account.ID)
+	if err := account.validate(true); err != nil {
 		return nil, err
 	}
-
-	switch resp.StatusCode {
-	case http.StatusOK:
-		var account = new(AwsAccount)
-		err = json.Unmarshal(responseBody, &account)
-		if err != nil {
-			return nil, err
-		}
-
-		return account, nil
-	case http.StatusUnauthorized:
-		return nil, ErrClientAuthenticationError
-	case http.StatusUnprocessableEntity:
-		return nil, fmt.Errorf("Bad Request. Please check if a AWS Account with this name `%s` already exists", account.Name)
-	default:
-		return nil, fmt.Errorf("Unknown Response with CloudHealth: `%d`", resp.StatusCode)
-	}
+	return awsAccounts.update(s, strconv.Itoa(account.ID), account, awsAccountNameConflict(account), opts)
 }
 
 // DeleteAwsAccount removes the AWS Account with the specified CloudHealth ID.
-func (s *Client) DeleteAwsAccount(id int) error {
-
-	relativeURL, _ := url.Parse(fmt.Sprintf("aws_accounts/%d?api_key=%s", id, s.ApiKey))
-	url := s.EndpointURL.ResolveReference(relativeURL)
-
-	req, err := http.NewRequest("DELETE", url.String(), nil)
-
-	client := &http.Client{
-		Timeout: time.Second * time.Duration(s.Timeout),
-	}
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
+func (s *Client) DeleteAwsAccount(id int, opts ...CallOption) (err error) {
+	defer annotate(&err, "DeleteAwsAccount", id)
+	return awsAccounts.delete(s, strconv.Itoa(id), nil, opts)
+}
 
-	switch resp.StatusCode {
-	case http.StatusOK:
-		return nil
-	case http.StatusNoContent:
+// awsAccountNameConflict reports the 422 returned when another AWS Account already has the account's name.
+func awsAccountNameConflict(account AwsAccount) func(resp *http.Response) error {
+	return func(resp *http.Response) error {
+		if resp.StatusCode == http.StatusUnprocessableEntity {
+			return fmt.Errorf("Bad Request. Please check if a AWS Account with this name `%s` already exists", account.Name)
+		}
 		return nil
-	case http.StatusNotFound:
-		return ErrAwsAccountNotFound
-	case http.StatusUnauthorized:
-		return ErrClientAuthenticationError
-	default:
-		return fmt.Errorf("Unknown Response with CloudHealth: `%d`", resp.StatusCode)
 	}
 }
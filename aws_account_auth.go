@@ -0,0 +1,84 @@
+package cloudhealth
+
+// Authentication protocols supported for AWS Accounts.
+const (
+	AwsAuthProtocolAccessKey  = "access_key"
+	AwsAuthProtocolAssumeRole = "assume_role"
+)
+
+// NewAssumeRoleAuth returns the authentication for an AWS Account integrated through an IAM Role.
+// The external ID is the one returned by GetAwsExternalID.
+func NewAssumeRoleAuth(arn, externalID string) AwsAccountAuthentication {
+	return AwsAccountAuthentication{
+		Protocol:             AwsAuthProtocolAssumeRole,
+		AssumeRoleArn:        arn,
+		AssumeRoleExternalID: externalID,
+	}
+}
+
+// NewAccessKeyAuth returns the authentication for an AWS Account integrated through IAM User access keys.
+func NewAccessKeyAuth(accessKey, secretKey string) AwsAccountAuthentication {
+	return AwsAccountAuthentication{
+		Protocol:  AwsAuthProtocolAccessKey,
+		AccessKey: accessKey,
+		SecretKey: secretKey,
+	}
+}
+
+// Validate checks that the fields set match the protocol, returning a *ValidationError otherwise.
+// Access key and assume role fields are mutually exclusive. An empty authentication is valid,
+// as it leaves the authentication of an existing AWS Account unchanged.
+func (a AwsAccountAuthentication) Validate() error {
+	return a.validate(false)
+}
+
+// validate checks the authentication like Validate. For an update, the secret key may be left out of the
+// access_key protocol: CloudHealth never returns it, so an AWS Account read back doesn't have it.
+func (a AwsAccountAuthentication) validate(update bool) error {
+	var problems []ValidationProblem
+	problem := func(field, message string) {
+		problems = append(problems, ValidationProblem{Path: "$.authentication." + field, Message: message})
+	}
+	secretKey := a.SecretKey
+	if secretKey == "" {
+		secretKey = a.SecreyKey
+	}
+	hasKeys := a.AccessKey != "" || secretKey != ""
+	hasRole := a.AssumeRoleArn != "" || a.AssumeRoleExternalID != ""
+
+	switch a.Protocol {
+	case "":
+		if hasKeys || hasRole {
+			problem("protocol", "is required when credentials are set")
+		}
+	case AwsAuthProtocolAccessKey:
+		if a.AccessKey == "" {
+			problem("access_key", "is required for the access_key protocol")
+		}
+		if secretKey == "" && !update {
+			problem("secret_key", "is required for the access_key protocol")
+		}
+		if hasRole {
+			problem("assume_role_arn", "must not be set with the access_key protocol")
+		}
+	case AwsAuthProtocolAssumeRole:
+		if a.AssumeRoleArn == "" {
+			problem("assume_role_arn", "is required for the assume_role protocol")
+		} else if !iamRoleArnPattern.MatchString(a.AssumeRoleArn) {
+			problem("assume_role_arn", "is not the ARN of an IAM Role")
+		}
+		if a.AssumeRoleExternalID == "" {
+			problem("assume_role_external_id", "is required for the assume_role protocol")
+		}
+		if hasKeys {
+			problem("access_key", "must not be set with the assume_role protocol")
+		}
+	default:
+		problem("protocol", "must be access_key or assume_role")
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
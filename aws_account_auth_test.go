@@ -0,0 +1,50 @@
+package cloudhealth
+
+import (
+	"testing"
+)
+
+func TestNewAuthValid(t *testing.T) {
+	for _, auth := range []AwsAccountAuthentication{
+		NewAssumeRoleAuth("arn:aws:iam::123456789012:role/CloudHealth", "externalid"),
+		NewAccessKeyAuth("AKIAEXAMPLE", "secret"),
+		{},
+	} {
+		if err := auth.Validate(); err != nil {
+			t.Errorf("Validate() returned an error for %s: %s", auth, err)
+		}
+	}
+}
+
+func TestAuthValidateMutuallyExclusive(t *testing.T) {
+	auth := NewAssumeRoleAuth("arn:aws:iam::123456789012:role/CloudHealth", "externalid")
+	auth.AccessKey = "AKIAEXAMPLE"
+
+	err := auth.Validate()
+	verr, ok := err.(*ValidationError)
+	if !ok {
+		t.Errorf("Validate() expected a *ValidationError, got %v", err)
+		return
+	}
+	if len(verr.Problems) != 1 || verr.Problems[0].Path != "$.authentication.access_key" {
+		t.Errorf("Validate() returned unexpected problems: %v", verr.Problems)
+	}
+}
+
+func TestAuthValidateMissingFields(t *testing.T) {
+	tests := []struct {
+		auth     AwsAccountAuthentication
+		problems int
+	}{
+		{AwsAccountAuthentication{Protocol: AwsAuthProtocolAccessKey}, 2},
+		{AwsAccountAuthentication{Protocol: AwsAuthProtocolAssumeRole, AssumeRoleArn: "arn"}, 2},
+		{AwsAccountAuthentication{AccessKey: "AKIAEXAMPLE"}, 1},
+		{AwsAccountAuthentication{Protocol: "password"}, 1},
+	}
+	for _, test := range tests {
+		verr, ok := test.auth.Validate().(*ValidationError)
+		if !ok || len(verr.Problems) != test.problems {
+			t.Errorf("Validate() expected %d problems for %#v, got %v", test.problems, test.auth, verr)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package cloudhealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ErrCloudTrailNotEnabled is returned when verifying the CloudTrail collection of an AWS Account without CloudTrail enabled.
+var ErrCloudTrailNotEnabled = errors.New("CloudTrail is not enabled on the AWS Account")
+
+// AwsAccountStatusError reports an AWS Account whose integration CloudHealth didn't report healthy in time.
+type AwsAccountStatusError struct {
+	ID     int
+	Status AwsAccountStatus // last status reported by CloudHealth
+	Err    error            // why waiting stopped, e.g. context.DeadlineExceeded
+}
+
+// Error implements error.
+func (e *AwsAccountStatusError) Error() string {
+	return fmt.Sprintf("AWS Account %d status is %s: %s", e.ID, e.Status, e.Err)
+}
+
+// Unwrap returns why waiting stopped.
+func (e *AwsAccountStatusError) Unwrap() error {
+	return e.Err
+}
+
+// VerifyAwsAccountCloudTrail checks that CloudHealth collects the CloudTrail logs configured on an AWS Account,
+// polling the account every interval until its status is green. When ctx is done first, the error is an
+// *AwsAccountStatusError holding the last status. CloudHealth reports a single status for the whole integration,
+// so a status other than green may also stem from other collection problems.
+func (s *Client) VerifyAwsAccountCloudTrail(ctx context.Context, id int, interval time.Duration) (err error) {
+	defer annotate(&err, "VerifyAwsAccountCloudTrail", id)
+	_, err = s.waitForAwsAccountHealthy(ctx, id, NewPoller(interval), func(account *AwsAccount) error {
+		if account.CloudTrail == nil || !account.CloudTrail.Enabled {
+			return ErrCloudTrailNotEnabled
+		}
+		return nil
+	})
+	return err
+}
+
+// waitForAwsAccountHealthy polls the AWS Account until its status is green, returning it.
+// check, when not nil, is called with every retrieved account and stops waiting when it returns an error.
+func (s *Client) waitForAwsAccountHealthy(ctx context.Context, id int, poller *Poller, check func(*AwsAccount) error) (*AwsAccount, error) {
+	var account *AwsAccount
+	var status AwsAccountStatus
+	err := s.withClock(poller).Poll(ctx, func(ctx context.Context) (bool, error) {
+		var err error
+		account, err = awsAccounts.get(s, strconv.Itoa(id), []CallOption{WithContext(ctx)})
+		if err != nil {
+			return false, err
+		}
+		if check != nil {
+			if err := check(account); err != nil {
+				return false, err
+			}
+		}
+		status = AwsAccountStatus{}
+		if account.Status != nil {
+			status = *account.Status
+		}
+		return status.Level == "green", nil
+	})
+	if ctxErr := ctx.Err(); ctxErr != nil && errors.Is(err, ctxErr) {
+		return nil, &AwsAccountStatusError{ID: id, Status: status, Err: ctxErr}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
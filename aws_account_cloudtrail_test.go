@@ -0,0 +1,121 @@
+package cloudhealth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestVerifyAwsAccountCloudTrail(t *testing.T) {
+	levels := []string{"red", "yellow", "green"}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account := defaultAWSAccount
+		account.CloudTrail = &AwsAccountCloudTrail{Enabled: true, Bucket: "trail", Prefix: "logs"}
+		account.Status = &AwsAccountStatus{Level: levels[0]}
+		if len(levels) > 1 {
+			levels = levels[1:]
+		}
+		body, _ := json.Marshal(account)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	clock := newFakeClock()
+	c.Clock = clock
+
+	if err := c.VerifyAwsAccountCloudTrail(context.Background(), defaultAWSAccount.ID, time.Minute); err != nil {
+		t.Errorf("VerifyAwsAccountCloudTrail() returned an error: %s", err)
+		return
+	}
+	if len(clock.sleeps) != 2 {
+		t.Errorf("VerifyAwsAccountCloudTrail() expected 2 waits, got %v", clock.sleeps)
+	}
+}
+
+func TestVerifyAwsAccountCloudTrailTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account := defaultAWSAccount
+		account.CloudTrail = &AwsAccountCloudTrail{Enabled: true, Bucket: "trail"}
+		account.Status = &AwsAccountStatus{Level: "red"}
+		body, _ := json.Marshal(account)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	c.Clock = cancelingClock{cancel: cancel}
+
+	err = c.VerifyAwsAccountCloudTrail(ctx, defaultAWSAccount.ID, time.Minute)
+	var statusErr *AwsAccountStatusError
+	if !errors.As(err, &statusErr) || statusErr.Status.Level != "red" || !errors.Is(err, context.Canceled) {
+		t.Errorf("VerifyAwsAccountCloudTrail() expected a red status error, got %v", err)
+	}
+}
+
+// cancelingClock cancels the context of a poll instead of sleeping between its attempts.
+type cancelingClock struct {
+	realClock
+	cancel context.CancelFunc
+}
+
+func (c cancelingClock) Sleep(ctx context.Context, d time.Duration) error {
+	c.cancel()
+	return ctx.Err()
+}
+
+func TestVerifyAwsAccountCloudTrailDeadline(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Second)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = c.VerifyAwsAccountCloudTrail(ctx, defaultAWSAccount.ID, time.Minute)
+	var statusErr *AwsAccountStatusError
+	if !errors.As(err, &statusErr) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("VerifyAwsAccountCloudTrail() expected a status error for the deadline, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected the poll request to be abandoned at the deadline, took %s", elapsed)
+	}
+}
+
+func TestVerifyAwsAccountCloudTrailNotEnabled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := json.Marshal(defaultAWSAccount)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if err := c.VerifyAwsAccountCloudTrail(context.Background(), defaultAWSAccount.ID, time.Minute); !errors.Is(err, ErrCloudTrailNotEnabled) {
+		t.Errorf("VerifyAwsAccountCloudTrail() expected ErrCloudTrailNotEnabled, got %v", err)
+	}
+}
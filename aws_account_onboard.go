@@ -0,0 +1,37 @@
+package cloudhealth
+
+import (
+	"context"
+	"time"
+)
+
+// CreateRoleFunc creates the IAM Role CloudHealth assumes in the AWS Account being onboarded, trusting CloudHealth
+// with the given external ID, and returns the role's ARN.
+type CreateRoleFunc func(ctx context.Context, externalID string) (roleArn string, err error)
+
+// OnboardAwsAccount enables the AWS Account in CloudHealth end to end: it generates the external ID, calls createRole
+// for the caller to create the IAM Role, creates the account with assume role authentication and polls it every
+// interval until CloudHealth reports it healthy. The Authentication of account is replaced; its other settings,
+// such as the name and CloudTrail configuration, are kept. When the account was created but didn't become healthy,
+// it is returned along with the error so the caller can remove it.
+func (s *Client) OnboardAwsAccount(ctx context.Context, account AwsAccount, createRole CreateRoleFunc, interval time.Duration) (created *AwsAccount, err error) {
+	defer annotate(&err, "OnboardAwsAccount", account.Name)
+	externalID, err := s.GetAwsExternalID(WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	roleArn, err := createRole(ctx, externalID)
+	if err != nil {
+		return nil, err
+	}
+	account.Authentication = NewAssumeRoleAuth(roleArn, externalID)
+	created, err = s.CreateAwsAccount(account, WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	healthy, err := s.waitForAwsAccountHealthy(ctx, created.ID, NewPoller(interval), nil)
+	if err != nil {
+		return created, err
+	}
+	return healthy, nil
+}
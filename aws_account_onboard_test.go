@@ -0,0 +1,106 @@
+package cloudhealth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestOnboardAwsAccount(t *testing.T) {
+	levels := []string{"red", "green"}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/aws_accounts/:id/generate_external_id":
+			w.Write([]byte(`{"generated_external_id":"externalid"}`))
+		case r.Method == "POST" && r.URL.Path == "/aws_accounts":
+			account := new(AwsAccount)
+			json.NewDecoder(r.Body).Decode(account)
+			if account.Authentication.AssumeRoleArn != "arn:aws:iam::123456789012:role/CloudHealth" || account.Authentication.AssumeRoleExternalID != "externalid" {
+				t.Errorf("Expected assume role authentication, got %+v", account.Authentication)
+			}
+			account.ID = 42
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(account)
+		case r.Method == "GET" && r.URL.Path == "/aws_accounts/42":
+			account := AwsAccount{ID: 42, Name: "Production", Status: &AwsAccountStatus{Level: levels[0]}}
+			levels = levels[1:]
+			json.NewEncoder(w).Encode(account)
+		default:
+			t.Errorf("Unexpected ‘%s’ request to ‘%s’", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.Clock = newFakeClock()
+
+	var roleExternalID string
+	account, err := c.OnboardAwsAccount(context.Background(), AwsAccount{Name: "Production"}, func(ctx context.Context, externalID string) (string, error) {
+		roleExternalID = externalID
+		return "arn:aws:iam::123456789012:role/CloudHealth", nil
+	}, time.Minute)
+	if err != nil {
+		t.Errorf("OnboardAwsAccount() returned an error: %s", err)
+		return
+	}
+	if roleExternalID != "externalid" {
+		t.Errorf("OnboardAwsAccount() expected the role to trust ‘externalid’, got ‘%s’", roleExternalID)
+	}
+	if account.ID != 42 || account.Status.Level != "green" {
+		t.Errorf("OnboardAwsAccount() expected the healthy account, got %s", account)
+	}
+}
+
+func TestOnboardAwsAccountRoleError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected no account to be created, got ‘%s’ request to ‘%s’", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"generated_external_id":"externalid"}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	errRole := errors.New("AccessDenied")
+	_, err = c.OnboardAwsAccount(context.Background(), AwsAccount{Name: "Production"}, func(ctx context.Context, externalID string) (string, error) {
+		return "", errRole
+	}, time.Minute)
+	if !errors.Is(err, errRole) {
+		t.Errorf("OnboardAwsAccount() expected the role error, got %v", err)
+	}
+}
+
+func TestOnboardAwsAccountCanceled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request with a canceled context, got ‘%s’ request to ‘%s’", r.Method, r.URL.Path)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = c.OnboardAwsAccount(ctx, AwsAccount{Name: "Production"}, func(ctx context.Context, externalID string) (string, error) {
+		t.Errorf("Expected no role to be created with a canceled context")
+		return "", nil
+	}, time.Minute)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("OnboardAwsAccount() expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package cloudhealth
+
+import "sort"
+
+// AwsAccountReconciliation compares the AWS Accounts enabled in CloudHealth with a list of AWS account IDs,
+// such as the accounts of an AWS Organization.
+type AwsAccountReconciliation struct {
+	Missing   []string     // AWS account IDs not enabled in CloudHealth
+	Unknown   []AwsAccount // accounts enabled in CloudHealth but not in the list
+	Unhealthy []AwsAccount // accounts in the list whose CloudHealth status isn't green
+}
+
+// ReconcileAwsAccounts compares the AWS Accounts enabled in CloudHealth, matched by OwnerID, with the AWS account IDs
+// in accountIDs, retrieving the accounts perPage at a time.
+func (s *Client) ReconcileAwsAccounts(accountIDs []string, perPage int, opts ...CallOption) (reconciliation *AwsAccountReconciliation, err error) {
+	defer annotate(&err, "ReconcileAwsAccounts", "")
+	expected := make(map[string]bool, len(accountIDs))
+	for _, id := range accountIDs {
+		expected[id] = true
+	}
+
+	reconciliation = &AwsAccountReconciliation{}
+	enabled := map[string]bool{}
+	err = s.awsAccountsPages(perPage, func(page []AwsAccount) bool {
+		for _, account := range page {
+			enabled[account.OwnerID] = true
+			switch {
+			case !expected[account.OwnerID]:
+				reconciliation.Unknown = append(reconciliation.Unknown, account)
+			case account.Status == nil || account.Status.Level != "green":
+				reconciliation.Unhealthy = append(reconciliation.Unhealthy, account)
+			}
+		}
+		return true
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	for id := range expected {
+		if !enabled[id] {
+			reconciliation.Missing = append(reconciliation.Missing, id)
+		}
+	}
+	sort.Strings(reconciliation.Missing)
+	return reconciliation, nil
+}
@@ -0,0 +1,41 @@
+package cloudhealth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestReconcileAwsAccounts(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(AwsAccounts{Accounts: []AwsAccount{
+			{ID: 1, OwnerID: "111111111111", Status: &AwsAccountStatus{Level: "green"}},
+			{ID: 2, OwnerID: "222222222222", Status: &AwsAccountStatus{Level: "red"}},
+			{ID: 3, OwnerID: "999999999999", Status: &AwsAccountStatus{Level: "green"}},
+		}})
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	reconciliation, err := c.ReconcileAwsAccounts([]string{"444444444444", "111111111111", "222222222222", "333333333333"}, 100)
+	if err != nil {
+		t.Errorf("ReconcileAwsAccounts() returned an error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(reconciliation.Missing, []string{"333333333333", "444444444444"}) {
+		t.Errorf("ReconcileAwsAccounts() returned unexpected missing accounts: %v", reconciliation.Missing)
+	}
+	if len(reconciliation.Unknown) != 1 || reconciliation.Unknown[0].ID != 3 {
+		t.Errorf("ReconcileAwsAccounts() returned unexpected unknown accounts: %v", reconciliation.Unknown)
+	}
+	if len(reconciliation.Unhealthy) != 1 || reconciliation.Unhealthy[0].ID != 2 {
+		t.Errorf("ReconcileAwsAccounts() returned unexpected unhealthy accounts: %v", reconciliation.Unhealthy)
+	}
+}
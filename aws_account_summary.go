@@ -0,0 +1,34 @@
+package cloudhealth
+
+// AwsAccountSummary counts the AWS Accounts enabled in CloudHealth.
+type AwsAccountSummary struct {
+	Total    int
+	ByStatus map[string]int // keyed by status level, "" for accounts without a reported status
+	ByType   map[string]int // keyed by account type
+}
+
+// GetAwsAccountSummary counts the AWS Accounts by status level and account type, retrieving them perPage at a time
+// without holding every account in memory.
+func (s *Client) GetAwsAccountSummary(perPage int, opts ...CallOption) (summary *AwsAccountSummary, err error) {
+	defer annotate(&err, "GetAwsAccountSummary", "")
+	summary = &AwsAccountSummary{
+		ByStatus: make(map[string]int),
+		ByType:   make(map[string]int),
+	}
+	err = s.awsAccountsPages(perPage, func(page []AwsAccount) bool {
+		for _, account := range page {
+			summary.Total++
+			level := ""
+			if account.Status != nil {
+				level = account.Status.Level
+			}
+			summary.ByStatus[level]++
+			summary.ByType[account.AccountType]++
+		}
+		return true
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
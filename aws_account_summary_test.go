@@ -0,0 +1,46 @@
+package cloudhealth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestGetAwsAccountSummary(t *testing.T) {
+	pages := map[string][]AwsAccount{
+		"1": {
+			{ID: 1, AccountType: "Consolidated", Status: &AwsAccountStatus{Level: "green"}},
+			{ID: 2, AccountType: "Linked", Status: &AwsAccountStatus{Level: "green"}},
+		},
+		"2": {
+			{ID: 3, AccountType: "Linked", Status: &AwsAccountStatus{Level: "red"}},
+		},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := json.Marshal(AwsAccounts{Accounts: pages[r.URL.Query().Get("page")]})
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	summary, err := c.GetAwsAccountSummary(2)
+	if err != nil {
+		t.Errorf("GetAwsAccountSummary() returned an error: %s", err)
+		return
+	}
+	expected := &AwsAccountSummary{
+		Total:    3,
+		ByStatus: map[string]int{"green": 2, "red": 1},
+		ByType:   map[string]int{"Consolidated": 1, "Linked": 2},
+	}
+	if !reflect.DeepEqual(summary, expected) {
+		t.Errorf("GetAwsAccountSummary() expected %+v, got %+v", expected, summary)
+	}
+}
@@ -2,10 +2,12 @@ package cloudhealth
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 )
 
@@ -55,7 +57,7 @@ func TestGetAllAwsAccountsOK(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	c, err := NewClient("apiKey", ts.URL)
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
 	if err != nil {
 		t.Errorf("NewClient() returned an error: %s", err)
 		return
@@ -88,7 +90,7 @@ func TestGetAwsAccountOK(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	c, err := NewClient("apiKey", ts.URL)
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
 	if err != nil {
 		t.Errorf("NewClient() returned an error: %s", err)
 		return
@@ -118,14 +120,14 @@ func TestGetAwsAccountDoesntExist(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	c, err := NewClient("apiKey", ts.URL)
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
 	if err != nil {
 		t.Errorf("NewClient() returned an error: %s", err)
 		return
 	}
 
 	_, err = c.GetAwsAccount(defaultAWSAccount.ID)
-	if err != ErrAwsAccountNotFound {
+	if !errors.Is(err, ErrAwsAccountNotFound) {
 		t.Errorf("GetAwsAccount() returned the wrong error: %s", err)
 		return
 	}
@@ -166,7 +168,7 @@ func TestCreateAwsAccountOk(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	c, err := NewClient("apiKey", ts.URL)
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
 	if err != nil {
 		t.Errorf("NewClient() returned an error: %s", err)
 		return
@@ -200,7 +202,7 @@ func TestUpdateAwsAccountAlreadyExists(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	c, err := NewClient("apiKey", ts.URL)
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
 	if err != nil {
 		t.Errorf("NewClient() returned an error: %s", err)
 		return
@@ -232,7 +234,7 @@ func TestUpdateAwsAccountOK(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	c, err := NewClient("apiKey", ts.URL)
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
 	if err != nil {
 		t.Errorf("NewClient() returned an error: %s", err)
 		return
@@ -270,7 +272,7 @@ func TestUpdateAwsAccountNameConflict(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	c, err := NewClient("apiKey", ts.URL)
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
 	if err != nil {
 		t.Errorf("NewClient() returned an error: %s", err)
 		return
@@ -296,7 +298,7 @@ func TestDeleteAwsAccountOK(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	c, err := NewClient("apiKey", ts.URL)
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
 	if err != nil {
 		t.Errorf("NewClient() returned an error: %s", err)
 		return
@@ -322,15 +324,149 @@ func TestDeleteAwsAccountDoesntExist(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	c, err := NewClient("apiKey", ts.URL)
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
 	if err != nil {
 		t.Errorf("NewClient() returned an error: %s", err)
 		return
 	}
 
 	err = c.DeleteAwsAccount(defaultAWSAccount.ID)
-	if err != ErrAwsAccountNotFound {
+	if !errors.Is(err, ErrAwsAccountNotFound) {
 		t.Errorf("DeleteAwsAccount() returned the wrong error: %s", err)
 		return
 	}
 }
+
+func TestGetAwsAccountsPagesStopEarly(t *testing.T) {
+	var allAWSAccounts []AwsAccount
+	for i := 0; i < 25; i++ {
+		account := defaultAWSAccount
+		account.ID = defaultAWSAccount.ID + i
+		allAWSAccounts = append(allAWSAccounts, account)
+	}
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		start := (page - 1) * perPage
+		end := start + perPage
+		if end > len(allAWSAccounts) {
+			end = len(allAWSAccounts)
+		}
+		w.WriteHeader(http.StatusOK)
+		body, _ := json.Marshal(AwsAccounts{Accounts: allAWSAccounts[start:end]})
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	var pages [][]AwsAccount
+	err = c.GetAwsAccountsPages(defaultPerPage, func(page []AwsAccount) bool {
+		pages = append(pages, page)
+		return len(pages) < 2
+	})
+	if err != nil {
+		t.Errorf("GetAwsAccountsPages() returned an error: %s", err)
+		return
+	}
+	if len(pages) != 2 || requests != 2 {
+		t.Errorf("GetAwsAccountsPages() expected to stop after 2 pages, got %d pages in %d requests", len(pages), requests)
+		return
+	}
+	if pages[1][0].ID != allAWSAccounts[defaultPerPage].ID {
+		t.Errorf("GetAwsAccountsPages() expected second page to start with ID `%d`, got `%d`", allAWSAccounts[defaultPerPage].ID, pages[1][0].ID)
+		return
+	}
+
+	all, err := c.GetAllAwsAccounts(defaultPerPage)
+	if err != nil {
+		t.Errorf("GetAllAwsAccounts() returned an error: %s", err)
+		return
+	}
+	if !sliceAwsAccountsEqual(all, allAWSAccounts) {
+		t.Errorf("GetAllAwsAccounts() expected %d accounts, got %d", len(allAWSAccounts), len(all))
+		return
+	}
+}
+
+func TestAwsAccountAuthenticationSecretKeyCompatibility(t *testing.T) {
+	for _, auth := range []AwsAccountAuthentication{
+		{Protocol: "access_key", SecretKey: "secret"},
+		{Protocol: "access_key", SecreyKey: "secret"},
+	} {
+		body, err := json.Marshal(auth)
+		if err != nil {
+			t.Errorf("Unable to marshal AwsAccountAuthentication: %s", err)
+			return
+		}
+		expected := `{"protocol":"access_key","secret_key":"secret"}`
+		if string(body) != expected {
+			t.Errorf("Expected AwsAccountAuthentication to marshal to `%s`, got `%s`", expected, body)
+		}
+	}
+
+	var auth AwsAccountAuthentication
+	if err := json.Unmarshal([]byte(`{"protocol":"access_key","secret_key":"secret"}`), &auth); err != nil {
+		t.Errorf("Unable to unmarshal AwsAccountAuthentication: %s", err)
+		return
+	}
+	if auth.SecretKey != "secret" || auth.SecreyKey != "secret" {
+		t.Errorf("Expected both SecretKey and SecreyKey to be populated, got %#v", auth)
+	}
+}
+
+func TestAwsAccountBillingJSON(t *testing.T) {
+	account := defaultAWSAccount
+	account.Authentication = NewAssumeRoleAuth("arn:aws:iam::123456789012:role/CloudHealth", "externalid")
+	account.Billing = &AwsAccountBilling{Bucket: "cur-reports"}
+	if err := validatePayload(awsAccountPayloadSchema, account); err != nil {
+		t.Errorf("validatePayload() rejected the billing configuration: %s", err)
+		return
+	}
+
+	body, _ := json.Marshal(account)
+	decoded := new(AwsAccount)
+	if err := json.Unmarshal(body, decoded); err != nil {
+		t.Errorf("Unable to unmarshal AWS Account, got `%s`, error:\n%s", body, err)
+		return
+	}
+	if decoded.Billing == nil || decoded.Billing.Bucket != "cur-reports" {
+		t.Errorf("Expected billing bucket ‘cur-reports’ in `%s`", body)
+	}
+}
+
+func TestUpdateAwsAccountReadBack(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// CloudHealth returns the access key of an account but never its secret key.
+		w.Write([]byte(`{"id":1,"name":"test","authentication":{"protocol":"access_key","access_key":"AKIAEXAMPLE"}}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	account, err := c.GetAwsAccount(1)
+	if err != nil {
+		t.Errorf("GetAwsAccount() returned an error: %s", err)
+		return
+	}
+	account.Name = "renamed"
+	if _, err := c.UpdateAwsAccount(*account); err != nil {
+		t.Errorf("UpdateAwsAccount() returned an error for an account read back: %s", err)
+	}
+
+	account.ID = 0
+	var verr *ValidationError
+	if _, err := c.CreateAwsAccount(*account); !errors.As(err, &verr) {
+		t.Errorf("CreateAwsAccount() expected a *ValidationError without the secret key, got %v", err)
+	}
+}
@@ -27,7 +27,7 @@ func TestGetAwsExternalIDOk(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	c, err := NewClient("apiKey", ts.URL)
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
 	if err != nil {
 		t.Errorf("NewClient() returned an error: %s", err)
 		return
@@ -0,0 +1,117 @@
+package cloudhealth
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting CloudHealth while the Client's CircuitBreaker is open.
+var ErrCircuitOpen = errors.New("CloudHealth circuit breaker is open")
+
+// CircuitBreaker stops requests from reaching CloudHealth after Threshold consecutive failures
+// (connection errors, timeouts or 5xx responses). Requests fail fast with ErrCircuitOpen until
+// Cooldown has elapsed, after which a single trial request is let through to probe the API again.
+type CircuitBreaker struct {
+	Threshold int
+	Cooldown  time.Duration
+	Clock     Clock // defaults to the Clock of the Client sending the request, or the system clock in Open
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker returns a CircuitBreaker tripping after threshold consecutive failures for cooldown.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		Threshold: threshold,
+		Cooldown:  cooldown,
+	}
+}
+
+// Open reports whether the breaker is currently rejecting requests.
+func (b *CircuitBreaker) Open() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.tripped() && (b.probing || b.now(nil).Sub(b.openedAt) < b.Cooldown)
+}
+
+// now returns the time of the breaker's Clock, falling back to clock and then to the system clock when nil.
+func (b *CircuitBreaker) now(clock Clock) time.Time {
+	if b.Clock != nil {
+		clock = b.Clock
+	}
+	if clock == nil {
+		return time.Now()
+	}
+	return clock.Now()
+}
+
+func (b *CircuitBreaker) tripped() bool {
+	return b.Threshold > 0 && b.failures >= b.Threshold
+}
+
+// allow reports whether a request may be sent, letting a single trial request through once the cool-down has elapsed.
+// The cool-down is measured with clock when the breaker has no Clock.
+func (b *CircuitBreaker) allow(clock Clock) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.tripped() {
+		return true
+	}
+	if b.probing || b.now(clock).Sub(b.openedAt) < b.Cooldown {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// release ends a request without counting its outcome, letting another trial request through if it was one.
+func (b *CircuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// record updates the breaker with the outcome of a request, timed with clock when the breaker has no Clock.
+func (b *CircuitBreaker) record(success bool, clock Clock) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if success {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.tripped() {
+		b.openedAt = b.now(clock)
+	}
+}
+
+// breakerTransport guards the next RoundTripper with a CircuitBreaker.
+type breakerTransport struct {
+	next    http.RoundTripper
+	breaker *CircuitBreaker
+	clock   Clock // of the Client, used when the breaker has none
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.breaker.allow(t.clock) {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, ErrCircuitOpen
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil && callerGaveUp(req.Context()) {
+		// The caller gave up, which tells nothing about the health of CloudHealth.
+		t.breaker.release()
+		return resp, err
+	}
+	t.breaker.record(err == nil && resp.StatusCode < http.StatusInternalServerError, t.clock)
+	return resp, err
+}
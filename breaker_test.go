@@ -0,0 +1,142 @@
+package cloudhealth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerTrips(t *testing.T) {
+	requests := 0
+	status := http.StatusServiceUnavailable
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(status)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	clock := newFakeClock()
+	c.Clock = clock
+	c.CircuitBreaker = NewCircuitBreaker(2, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if err := c.DeleteAwsAccount(defaultAWSAccount.ID); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("DeleteAwsAccount() returned the wrong error: %v", err)
+			return
+		}
+	}
+	if err := c.DeleteAwsAccount(defaultAWSAccount.ID); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("DeleteAwsAccount() returned the wrong error: %v", err)
+		return
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 upstream requests while open, got %d", requests)
+	}
+
+	// After the cool-down a trial request is let through and closes the breaker on success
+	clock.Advance(time.Minute)
+	status = http.StatusOK
+	if err := c.DeleteAwsAccount(defaultAWSAccount.ID); err != nil {
+		t.Errorf("DeleteAwsAccount() returned an error: %s", err)
+		return
+	}
+	if c.CircuitBreaker.Open() {
+		t.Errorf("Expected the circuit breaker to close after a successful trial request")
+	}
+}
+
+func TestCircuitBreakerReopensOnFailedProbe(t *testing.T) {
+	clock := newFakeClock()
+	b := NewCircuitBreaker(1, time.Minute)
+	b.Clock = clock
+
+	b.record(false, nil)
+	if b.allow(nil) {
+		t.Errorf("Expected the circuit breaker to reject requests while open")
+	}
+	clock.Advance(time.Minute)
+	if !b.allow(nil) {
+		t.Errorf("Expected the circuit breaker to allow a trial request after the cool-down")
+	}
+	if b.allow(nil) {
+		t.Errorf("Expected the circuit breaker to allow only one trial request")
+	}
+	b.record(false, nil)
+	if !b.Open() {
+		t.Errorf("Expected the circuit breaker to reopen after a failed trial request")
+	}
+}
+
+func TestCircuitBreakerIgnoresCallerTimeouts(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("slow") != "" {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		w.Write([]byte(`{"id":1,"name":"test"}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.CircuitBreaker = NewCircuitBreaker(2, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		_, err := c.GetAwsAccount(1, WithContext(ctx), WithQueryParam("slow", "1"))
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("GetAwsAccount() expected context.DeadlineExceeded, got %v", err)
+		}
+	}
+	if _, err := c.GetAwsAccount(1); err != nil {
+		t.Errorf("GetAwsAccount() returned an error after caller timeouts: %s", err)
+	}
+}
+
+func TestCircuitBreakerTripsOnClientTimeouts(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL), WithTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.CircuitBreaker = NewCircuitBreaker(2, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetAwsAccount(1); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("GetAwsAccount() returned the wrong error: %v", err)
+			return
+		}
+	}
+	if _, err := c.GetAwsAccount(1, WithCallTimeout(time.Second)); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("GetAwsAccount() expected ErrCircuitOpen after timeouts, got %v", err)
+	}
+	if requests := atomic.LoadInt32(&requests); requests != 2 {
+		t.Errorf("Expected 2 upstream requests, got %d", requests)
+	}
+}
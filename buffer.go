@@ -0,0 +1,113 @@
+package cloudhealth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// maxPooledBufferSize keeps unusually large payloads from pinning memory in the pool.
+const maxPooledBufferSize = 1 << 20
+
+// bufferPool holds the buffers request payloads are encoded into.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// pooledPayload is a request payload encoded into a pooled buffer. The transport may read it several times,
+// through GetBody, to resend the request on a new connection or follow a redirect, so the buffer only goes back
+// to the pool once the request is finished and every reader of the payload is closed.
+type pooledPayload struct {
+	buf *bytes.Buffer
+
+	mu       sync.Mutex
+	readers  int
+	finished bool
+}
+
+// reader returns a new reader of the payload, for the request body or GetBody.
+func (p *pooledPayload) reader() (io.ReadCloser, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.finished {
+		return nil, errors.New("Request payload read after the request finished")
+	}
+	p.readers++
+	return &payloadReader{Reader: bytes.NewReader(p.buf.Bytes()), payload: p}, nil
+}
+
+// finish tells that the request of the payload is done, so no new reader is needed.
+func (p *pooledPayload) finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.finished = true
+	p.release()
+}
+
+// release puts the buffer back into the pool once it is no longer read. p.mu must be held.
+func (p *pooledPayload) release() {
+	if !p.finished || p.readers > 0 || p.buf == nil {
+		return
+	}
+	if p.buf.Cap() <= maxPooledBufferSize {
+		bufferPool.Put(p.buf)
+	}
+	p.buf = nil
+}
+
+// payloadReader is a reader of a pooledPayload.
+type payloadReader struct {
+	*bytes.Reader
+	payload *pooledPayload
+	once    sync.Once
+}
+
+// Close implements io.Closer.
+func (r *payloadReader) Close() error {
+	r.once.Do(func() {
+		r.payload.mu.Lock()
+		defer r.payload.mu.Unlock()
+		r.payload.readers--
+		r.payload.release()
+	})
+	return nil
+}
+
+// newJSONRequest returns a request with v encoded as its JSON body.
+// finishRequest must be called once the request is done for the encoding buffer to be reused.
+func newJSONRequest(ctx context.Context, method, url string, v interface{}) (*http.Request, error) {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	if err := json.NewEncoder(buf).Encode(v); err != nil {
+		bufferPool.Put(buf)
+		return nil, err
+	}
+	payload := &pooledPayload{buf: buf}
+	body, _ := payload.reader()
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		body.Close()
+		payload.finish()
+		return nil, err
+	}
+	req.ContentLength = int64(buf.Len())
+	req.GetBody = payload.reader
+	req.Header.Add("Content-Type", "application/json")
+	return req, nil
+}
+
+// finishRequest returns a function to call once req is done, releasing its payload when newJSONRequest built it.
+// It must be taken before sending req, as RoundTrippers such as recording Middleware may replace its Body.
+func finishRequest(req *http.Request) func() {
+	if body, ok := req.Body.(*payloadReader); ok {
+		return body.payload.finish
+	}
+	return func() {}
+}
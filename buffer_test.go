@@ -0,0 +1,109 @@
+package cloudhealth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewJSONRequest(t *testing.T) {
+	req, err := newJSONRequest(context.Background(), "POST", "https://api.foo.bar/aws_accounts", defaultAWSAccount)
+	if err != nil {
+		t.Errorf("newJSONRequest() returned an error: %s", err)
+		return
+	}
+	if ctype := req.Header.Get("Content-Type"); ctype != "application/json" {
+		t.Errorf("Expected request to be content-type ‘application/json’, got ‘%s’", ctype)
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		t.Errorf("Unable to read request body: %s", err)
+		return
+	}
+	req.Body.Close()
+	if req.ContentLength != int64(len(body)) {
+		t.Errorf("Unexpected ContentLength: %d != %d", req.ContentLength, len(body))
+	}
+
+	account := new(AwsAccount)
+	if err := json.Unmarshal(body, &account); err != nil {
+		t.Errorf("Unable to unmarshal AwsAccount, got `%s`", body)
+		return
+	}
+	if *account != defaultAWSAccount {
+		t.Errorf("newJSONRequest() encoded `%s`", body)
+	}
+}
+
+func TestNewJSONRequestGetBody(t *testing.T) {
+	req, err := newJSONRequest(context.Background(), "PUT", "https://api.foo.bar/aws_accounts/1", defaultAWSAccount)
+	if err != nil {
+		t.Errorf("newJSONRequest() returned an error: %s", err)
+		return
+	}
+	finish := finishRequest(req)
+	first, _ := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+
+	if req.GetBody == nil {
+		t.Errorf("newJSONRequest() expected GetBody to be set")
+		return
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		t.Errorf("GetBody() returned an error: %s", err)
+		return
+	}
+	finish()
+	// The payload is still read, so it must not be back in the pool yet.
+	bufferPool.Get().(*bytes.Buffer).WriteString("overwritten")
+	replayed, _ := ioutil.ReadAll(body)
+	body.Close()
+	if !bytes.Equal(first, replayed) {
+		t.Errorf("GetBody() expected `%s`, got `%s`", first, replayed)
+	}
+	if _, err := req.GetBody(); err == nil {
+		t.Errorf("GetBody() expected an error after the request finished")
+	}
+}
+
+func TestUpdateFollowsTemporaryRedirect(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/aws_accounts/1" {
+			http.Redirect(w, r, "/v2/aws_accounts/1", http.StatusTemporaryRedirect)
+			return
+		}
+		io.Copy(w, r.Body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	updated, err := c.UpdateAwsAccount(AwsAccount{ID: 1, Name: "renamed"})
+	if err != nil {
+		t.Errorf("UpdateAwsAccount() returned an error: %s", err)
+		return
+	}
+	if updated.Name != "renamed" {
+		t.Errorf("UpdateAwsAccount() expected the payload to be resent after the redirect, got %+v", updated)
+	}
+}
+
+func BenchmarkNewJSONRequest(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		req, _ := newJSONRequest(context.Background(), "POST", "https://api.foo.bar/aws_accounts", defaultAWSAccount)
+		finish := finishRequest(req)
+		ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		finish()
+	}
+}
@@ -0,0 +1,160 @@
+package cloudhealth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BulkOperation is a single create, update or delete run by RunBulk.
+type BulkOperation struct {
+	Name string // identifies the operation in the BulkReport, e.g. "CreateAwsAccount(Production)"
+	Run  func(ctx context.Context) error
+}
+
+// BulkOptions control how RunBulk runs its operations.
+type BulkOptions struct {
+	Concurrency int           // operations in flight at once, one when <= 0
+	Interval    time.Duration // minimum time between starting two operations; zero for none
+}
+
+// BulkResult is the outcome of one BulkOperation.
+type BulkResult struct {
+	Name     string
+	Err      error // nil on success, the context's error when the operation never started
+	Duration time.Duration
+}
+
+// BulkReport lists the outcome of every operation given to RunBulk, in the same order.
+type BulkReport struct {
+	Results []BulkResult
+}
+
+// Succeeded returns the results of the operations that succeeded.
+func (r *BulkReport) Succeeded() []BulkResult {
+	return r.filter(func(result BulkResult) bool { return result.Err == nil })
+}
+
+// Failed returns the results of the operations that failed or never started.
+func (r *BulkReport) Failed() []BulkResult {
+	return r.filter(func(result BulkResult) bool { return result.Err != nil })
+}
+
+func (r *BulkReport) filter(keep func(BulkResult) bool) []BulkResult {
+	var results []BulkResult
+	for _, result := range r.Results {
+		if keep(result) {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// Err returns nil when all operations succeeded, or an error counting the failures and wrapping the first one.
+func (r *BulkReport) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d bulk operations failed, first %s: %w", len(failed), len(r.Results), failed[0].Name, failed[0].Err)
+}
+
+// RunBulk runs operations with at most opts.Concurrency in flight, starting them at most every opts.Interval
+// as measured by the Client's Clock. A failing operation doesn't stop the others; once ctx is done, operations
+// not yet started are reported failed with its error. The report is complete when RunBulk returns.
+func (s *Client) RunBulk(ctx context.Context, operations []BulkOperation, opts BulkOptions) *BulkReport {
+	clock := s.clock()
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	report := &BulkReport{Results: make([]BulkResult, len(operations))}
+
+	var wg sync.WaitGroup
+	for i, op := range operations {
+		report.Results[i].Name = op.Name
+		if i > 0 && opts.Interval > 0 {
+			if err := clock.Sleep(ctx, opts.Interval); err != nil {
+				report.Results[i].Err = err
+				continue
+			}
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if err := ctx.Err(); err != nil {
+			report.Results[i].Err = err
+			continue
+		}
+		wg.Add(1)
+		go func(result *BulkResult, op BulkOperation) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			start := clock.Now()
+			result.Err = op.Run(ctx)
+			result.Duration = clock.Now().Sub(start)
+		}(&report.Results[i], op)
+	}
+	wg.Wait()
+	return report
+}
+
+// BulkCreateAwsAccount returns a BulkOperation creating account.
+func (s *Client) BulkCreateAwsAccount(account AwsAccount, opts ...CallOption) BulkOperation {
+	return s.bulkOperation("CreateAwsAccount", account.Name, opts, func(opts []CallOption) error {
+		_, err := s.CreateAwsAccount(account, opts...)
+		return err
+	})
+}
+
+// BulkUpdateAwsAccount returns a BulkOperation updating account.
+func (s *Client) BulkUpdateAwsAccount(account AwsAccount, opts ...CallOption) BulkOperation {
+	return s.bulkOperation("UpdateAwsAccount", account.ID, opts, func(opts []CallOption) error {
+		_, err := s.UpdateAwsAccount(account, opts...)
+		return err
+	})
+}
+
+// BulkDeleteAwsAccount returns a BulkOperation deleting the AWS Account.
+func (s *Client) BulkDeleteAwsAccount(id int, opts ...CallOption) BulkOperation {
+	return s.bulkOperation("DeleteAwsAccount", id, opts, func(opts []CallOption) error {
+		return s.DeleteAwsAccount(id, opts...)
+	})
+}
+
+// BulkCreatePerspective returns a BulkOperation creating perspective.
+func (s *Client) BulkCreatePerspective(perspective *Perspective, opts ...CallOption) BulkOperation {
+	return s.bulkOperation("CreatePerspective", perspective.Schema.Name, opts, func(opts []CallOption) error {
+		_, err := s.CreatePerspective(perspective, opts...)
+		return err
+	})
+}
+
+// BulkUpdatePerspective returns a BulkOperation updating the Perspective.
+func (s *Client) BulkUpdatePerspective(id string, perspective *Perspective, opts ...CallOption) BulkOperation {
+	return s.bulkOperation("UpdatePerspective", id, opts, func(opts []CallOption) error {
+		_, err := s.UpdatePerspective(id, perspective, opts...)
+		return err
+	})
+}
+
+// BulkDeletePerspective returns a BulkOperation deleting the Perspective.
+func (s *Client) BulkDeletePerspective(id string, opts ...CallOption) BulkOperation {
+	return s.bulkOperation("DeletePerspective", id, opts, func(opts []CallOption) error {
+		return s.DeletePerspective(id, opts...)
+	})
+}
+
+// bulkOperation returns a BulkOperation named like the OperationError of op, calling run with the context of
+// the bulk run added to opts.
+func (s *Client) bulkOperation(op string, resource interface{}, opts []CallOption, run func([]CallOption) error) BulkOperation {
+	return BulkOperation{
+		Name: fmt.Sprintf("%s(%v)", op, resource),
+		Run: func(ctx context.Context) error {
+			return run(append([]CallOption{WithContext(ctx)}, opts...))
+		},
+	}
+}
@@ -0,0 +1,120 @@
+package cloudhealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunBulkPartialFailure(t *testing.T) {
+	c, err := NewClient("apiKey", WithEndpoint("https://api.foo.bar"))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	errOp := errors.New("failed")
+	var inFlight, maxInFlight int32
+	op := func(name string, err error) BulkOperation {
+		return BulkOperation{Name: name, Run: func(ctx context.Context) error {
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				max := atomic.LoadInt32(&maxInFlight)
+				if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+					break
+				}
+			}
+			atomic.AddInt32(&inFlight, -1)
+			return err
+		}}
+	}
+
+	report := c.RunBulk(context.Background(), []BulkOperation{op("a", nil), op("b", errOp), op("c", nil), op("d", nil)}, BulkOptions{Concurrency: 2})
+	if maxInFlight > 2 {
+		t.Errorf("RunBulk() expected at most 2 operations in flight, got %d", maxInFlight)
+	}
+	if len(report.Succeeded()) != 3 {
+		t.Errorf("RunBulk() expected 3 successes, got %v", report.Succeeded())
+	}
+	failed := report.Failed()
+	if len(failed) != 1 || failed[0].Name != "b" || failed[0].Err != errOp {
+		t.Errorf("RunBulk() expected b to fail, got %v", failed)
+	}
+	if err := report.Err(); !errors.Is(err, errOp) {
+		t.Errorf("Err() expected to wrap the failure, got %v", err)
+	}
+}
+
+func TestRunBulkInterval(t *testing.T) {
+	c, err := NewClient("apiKey", WithEndpoint("https://api.foo.bar"))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	clock := newFakeClock()
+	c.Clock = clock
+	noop := BulkOperation{Name: "noop", Run: func(ctx context.Context) error { return nil }}
+
+	report := c.RunBulk(context.Background(), []BulkOperation{noop, noop, noop}, BulkOptions{Interval: time.Second})
+	if err := report.Err(); err != nil {
+		t.Errorf("RunBulk() returned an error: %s", err)
+	}
+	if expected := []time.Duration{time.Second, time.Second}; !reflect.DeepEqual(clock.sleeps, expected) {
+		t.Errorf("RunBulk() expected waits %v, got %v", expected, clock.sleeps)
+	}
+}
+
+func TestRunBulkCanceled(t *testing.T) {
+	c, err := NewClient("apiKey", WithEndpoint("https://api.foo.bar"))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ops := []BulkOperation{
+		{Name: "a", Run: func(ctx context.Context) error { cancel(); return nil }},
+		{Name: "b", Run: func(ctx context.Context) error { return nil }},
+	}
+
+	report := c.RunBulk(ctx, ops, BulkOptions{})
+	if report.Results[0].Err != nil || report.Results[1].Err != context.Canceled {
+		t.Errorf("RunBulk() expected b not to start, got %v", report.Results)
+	}
+}
+
+func TestRunBulkAcrossResources(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "DELETE /aws_accounts/1":
+			w.WriteHeader(http.StatusOK)
+		case fmt.Sprintf("DELETE /perspective_schemas/%s", defaultPerspectiveID):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("Unexpected request ‘%s %s’", r.Method, r.URL.EscapedPath())
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	report := c.RunBulk(context.Background(), []BulkOperation{
+		c.BulkDeleteAwsAccount(1),
+		c.BulkDeletePerspective(defaultPerspectiveID),
+	}, BulkOptions{Concurrency: 2})
+	if succeeded := report.Succeeded(); len(succeeded) != 1 || succeeded[0].Name != "DeleteAwsAccount(1)" {
+		t.Errorf("RunBulk() expected DeleteAwsAccount(1) to succeed, got %v", succeeded)
+	}
+	if failed := report.Failed(); len(failed) != 1 || !errors.Is(failed[0].Err, ErrPerspectiveNotFound) {
+		t.Errorf("RunBulk() expected DeletePerspective to fail with ErrPerspectiveNotFound, got %v", failed)
+	}
+}
@@ -0,0 +1,118 @@
+package cloudhealth
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// CachedResponse is a GET response stored by a ResponseCache for conditional requests.
+type CachedResponse struct {
+	ETag       string
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// ResponseCache stores GET responses keyed by request URL. When a Client has a cache,
+// GET requests are sent with If-None-Match and the cached response is replayed on a 304 Not Modified.
+type ResponseCache interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, resp *CachedResponse)
+	Delete(key string)
+}
+
+// MemoryCache is a ResponseCache held in memory. It is safe for concurrent use.
+type MemoryCache struct {
+	mu        sync.RWMutex
+	responses map[string]*CachedResponse
+}
+
+// NewMemoryCache returns an empty MemoryCache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		responses: make(map[string]*CachedResponse),
+	}
+}
+
+// Get returns the response cached for key, if any.
+func (c *MemoryCache) Get(key string) (*CachedResponse, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	resp, ok := c.responses[key]
+	return resp, ok
+}
+
+// Set caches resp for key.
+func (c *MemoryCache) Set(key string, resp *CachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.responses[key] = resp
+}
+
+// Delete removes the response cached for key.
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.responses, key)
+}
+
+// cachingTransport sends conditional GET requests and replays cached responses on 304 Not Modified.
+// Any other request to a URL evicts the response cached for it.
+type cachingTransport struct {
+	next  http.RoundTripper
+	cache ResponseCache
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := requestKey(req)
+	if req.Method != "GET" {
+		t.cache.Delete(key)
+		return t.next.RoundTrip(req)
+	}
+
+	cached, ok := t.cache.Get(key)
+	if ok {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && ok:
+		resp.Body.Close()
+		return &http.Response{
+			Status:        http.StatusText(cached.StatusCode),
+			StatusCode:    cached.StatusCode,
+			Proto:         resp.Proto,
+			ProtoMajor:    resp.ProtoMajor,
+			ProtoMinor:    resp.ProtoMinor,
+			Header:        cached.Header.Clone(),
+			Body:          ioutil.NopCloser(bytes.NewReader(cached.Body)),
+			ContentLength: int64(len(cached.Body)),
+			Request:       req,
+		}, nil
+	case resp.StatusCode == http.StatusOK && resp.Header.Get("ETag") != "":
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		t.cache.Set(key, &CachedResponse{
+			ETag:       resp.Header.Get("ETag"),
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       body,
+		})
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+		return resp, nil
+	default:
+		return resp, nil
+	}
+}
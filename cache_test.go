@@ -0,0 +1,82 @@
+package cloudhealth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCacheReplaysNotModified(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests > 1 {
+			if r.Header.Get("If-None-Match") != `"v1"` {
+				t.Errorf("Expected request with If-None-Match ‘\"v1\"’, got ‘%s’", r.Header.Get("If-None-Match"))
+			}
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusOK)
+		body, _ := json.Marshal(defaultAWSAccount)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.Cache = NewMemoryCache()
+
+	for i := 0; i < 2; i++ {
+		returnedAwsAccount, err := c.GetAwsAccount(defaultAWSAccount.ID)
+		if err != nil {
+			t.Errorf("GetAwsAccount() returned an error: %s", err)
+			return
+		}
+		if returnedAwsAccount.ID != defaultAWSAccount.ID {
+			t.Errorf("GetAwsAccount() expected ID `%d`, got `%d`", defaultAWSAccount.ID, returnedAwsAccount.ID)
+			return
+		}
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 upstream requests, got %d", requests)
+	}
+}
+
+func TestCacheEvictedByWrite(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.Header.Get("If-None-Match") != "" {
+			t.Errorf("Expected no If-None-Match after a write, got ‘%s’", r.Header.Get("If-None-Match"))
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusOK)
+		body, _ := json.Marshal(defaultAWSAccount)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.Cache = NewMemoryCache()
+
+	if _, err := c.GetAwsAccount(defaultAWSAccount.ID); err != nil {
+		t.Errorf("GetAwsAccount() returned an error: %s", err)
+		return
+	}
+	if _, err := c.UpdateAwsAccount(defaultAWSAccount); err != nil {
+		t.Errorf("UpdateAwsAccount() returned an error: %s", err)
+		return
+	}
+	if _, err := c.GetAwsAccount(defaultAWSAccount.ID); err != nil {
+		t.Errorf("GetAwsAccount() returned an error: %s", err)
+		return
+	}
+}
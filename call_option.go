@@ -0,0 +1,67 @@
+package cloudhealth
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// CallOption customizes a single call of a Client method.
+type CallOption func(c *apiCall)
+
+// WithApiKey authenticates the call with apiKey instead of the Client's API key,
+// e.g. to briefly act with a customer-specific key without building a new Client.
+func WithApiKey(apiKey string) CallOption {
+	return func(c *apiCall) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithQueryParam sets the query parameter name of the call, e.g. to filter or sort a listing on the server
+// where CloudHealth supports it. Parameters set by the SDK itself, such as the pagination of listings, are kept.
+func WithQueryParam(name, value string) CallOption {
+	return func(c *apiCall) {
+		if c.query.Has(name) {
+			return
+		}
+		query := url.Values{}
+		for k, v := range c.query {
+			query[k] = v
+		}
+		query.Set(name, value)
+		c.query = query
+	}
+}
+
+// WithContext makes the call with ctx, so that it is abandoned, along with any wait before it is sent or retried,
+// as soon as ctx is canceled or its deadline passes.
+func WithContext(ctx context.Context) CallOption {
+	return func(c *apiCall) {
+		c.ctx = ctx
+	}
+}
+
+// WithStartPage makes a listing start at the given page, e.g. the NextPage of a *ListInterruptedError.
+func WithStartPage(page int) CallOption {
+	return func(c *apiCall) {
+		c.startPage = page
+	}
+}
+
+// WithCallTimeout overrides the Client's Timeout for the requests of the call, e.g. for large Perspective
+// updates known to take longer than the default 15 seconds.
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return func(c *apiCall) {
+		c.timeout = timeout
+	}
+}
+
+// WithIdempotencyKey makes repeated creates with the same key create a single resource. After a create failed
+// without telling whether CloudHealth received it, such as a timeout, the next create with the key first looks
+// for a resource matching the request, by OwnerID or name for AWS Accounts and by name for Perspectives,
+// returning it instead of creating a duplicate. Keys are remembered by the Client for its lifetime.
+func WithIdempotencyKey(key string) CallOption {
+	return func(c *apiCall) {
+		c.idempotencyKey = key
+	}
+}
@@ -0,0 +1,97 @@
+package cloudhealth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithApiKey(t *testing.T) {
+	var keys []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, bearerToken(r))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("partnerKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if err := c.DeleteAwsAccount(1, WithApiKey("customerKey")); err != nil {
+		t.Errorf("DeleteAwsAccount() returned an error: %s", err)
+		return
+	}
+	if err := c.DeleteAwsAccount(1); err != nil {
+		t.Errorf("DeleteAwsAccount() returned an error: %s", err)
+		return
+	}
+	if len(keys) != 2 || keys[0] != "customerKey" || keys[1] != "partnerKey" {
+		t.Errorf("Expected the override to apply to a single call, got API keys %v", keys)
+	}
+}
+
+func TestWithQueryParam(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("sort") != "name" || q.Get("per_page") != "10" || q.Get("page") != "1" {
+			t.Errorf("Expected the query parameter alongside pagination, got ‘%s’", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"aws_accounts":[]}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if _, err := c.GetAllAwsAccounts(10, WithQueryParam("sort", "name"), WithQueryParam("per_page", "100")); err != nil {
+		t.Errorf("GetAllAwsAccounts() returned an error: %s", err)
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request with a canceled context")
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := c.GetAwsAccount(1, WithContext(ctx)); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetAwsAccount() expected context.Canceled, got %v", err)
+	}
+}
+
+func TestWithCallTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte(`{"generated_external_id":"externalid"}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL), WithTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if _, err := c.GetAwsExternalID(); err == nil {
+		t.Errorf("GetAwsExternalID() expected the Client's timeout to expire")
+	}
+	if _, err := c.GetAwsExternalID(WithCallTimeout(5 * time.Second)); err != nil {
+		t.Errorf("GetAwsExternalID() returned an error with a longer call timeout: %s", err)
+	}
+}
@@ -2,35 +2,260 @@
 package cloudhealth
 
 import (
+	"crypto/tls"
 	"errors"
+	"fmt"
+	"net"
+	"net/http"
 	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
 )
 
-var defaultTimeout int = 15
+var defaultTimeout = 15 * time.Second
+
+// Version is the version of this SDK, sent in the User-Agent header of every request.
+const Version = "0.1.0"
+
+// defaultUserAgent identifies requests made by this SDK to CloudHealth.
+const defaultUserAgent = "cloudhealth-sdk-go/" + Version
 
 // Client communicates with the CloudHealth API.
+// Responses are requested gzip compressed and are transparently decompressed.
 type Client struct {
 	ApiKey      string
 	EndpointURL *url.URL
-	Timeout     int
+	Timeout     time.Duration // must be set before the first request is made
+
+	// UserAgent, when set, is appended to the SDK's own "cloudhealth-sdk-go/<Version>" User-Agent,
+	// identifying the application making the requests.
+	UserAgent string
+
+	// AuthMode selects whether the API key is sent as a bearer token, the default, or as the api_key query parameter.
+	AuthMode AuthMode
+
+	// ApiKeyProvider, when set, is called before every request for the API key, replacing ApiKey.
+	// This lets keys fetched from a secret store rotate without recreating the Client;
+	// providers doing expensive lookups should cache the key themselves.
+	ApiKeyProvider func() (string, error)
+
+	// BasePath is prefixed to every API route, e.g. "/cloudhealth/v1" when CloudHealth is reached through a gateway.
+	// It is appended to the path of EndpointURL.
+	BasePath string
+
+	// Transport tuning for the connections shared by all requests of this Client.
+	// These must be set before the first request is made; zero values keep the net/http defaults.
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+	TLSHandshakeTimeout time.Duration
+	DialTimeout         time.Duration
+	TLSConfig           *tls.Config                           // e.g. trusting a corporate CA bundle
+	Proxy               func(*http.Request) (*url.URL, error) // defaults to http.ProxyFromEnvironment
+
+	// CoalesceGets makes concurrent identical GET requests share a single upstream call.
+	CoalesceGets bool
+
+	// Cache enables conditional GET requests, replaying the cached response when CloudHealth answers 304 Not Modified.
+	Cache ResponseCache
+
+	// Failover, when set, sends requests to a secondary endpoint while the EndpointURL can't be reached.
+	Failover *Failover
+
+	// CircuitBreaker, when set, fails requests fast during CloudHealth outages.
+	CircuitBreaker *CircuitBreaker
+
+	// MaxPerspectiveSize is the largest serialized Perspective, in bytes, created or updated by the Client. Larger
+	// Perspectives, typically generated from many tag values, are rejected with a *PayloadTooLargeError before
+	// sending. Zero means DefaultMaxPerspectiveSize; negative values disable the check.
+	MaxPerspectiveSize int64
+
+	// ValidatePayloads checks create and update payloads against embedded JSON Schemas before sending them,
+	// returning a *ValidationError locating every structural problem.
+	ValidatePayloads bool
+
+	// Clock is the source of time for timing logic such as circuit breaker cool-downs. Defaults to the real time.
+	Clock Clock
+
+	// StatsHook, when set, is called after every request with its endpoint, status and latency.
+	StatsHook func(RequestStats)
+
+	// Retry, when set, retries failed requests.
+	Retry *RetryPolicy
+
+	// RateLimitBudget is the total time a call waits for CloudHealth to stop throttling it with 429 Too Many
+	// Requests before failing with ErrRateLimited. Zero means DefaultRateLimitBudget; negative values disable waiting.
+	RateLimitBudget time.Duration
+
+	// AuditSink, when set, records every POST, PUT and DELETE request with its outcome.
+	AuditSink AuditSink
+
+	// RequestIDFunc generates the correlation ID sent with each request in the X-Request-Id header.
+	// Defaults to a random ID; requests already carrying the header (e.g. set by Middleware) keep theirs.
+	RequestIDFunc func() string
+
+	// Middleware is layered around the HTTP transport, the first Middleware being the outermost.
+	Middleware []Middleware
+
+	clientOnce     sync.Once
+	client         *http.Client      // shared by every request
+	baseTransport  http.RoundTripper // shared by the Clients of a ClientPool instead of a transport of their own
+	baseHTTPClient *http.Client      // given WithHTTPClient
+	connTransport  http.RoundTripper // innermost layer of transport, holding the connections
+
+	mu           sync.RWMutex
+	lastResponse *ResponseMetadata
+	rateLimit    *RateLimit
+	throttled    time.Time // until when CloudHealth asked the Client's calls to hold off
+
+	idempotencyMu sync.Mutex
+	idempotency   map[string]*idempotencyEntry // outcome of creates by idempotency key
 }
 
 // ErrClientAuthenticationError is returned for authentication errors with the API.
 var ErrClientAuthenticationError = errors.New("Authentication Error with CloudHealth")
 
-// NewClient returns a new cloudhealth.Client for accessing the CloudHealth API.
-func NewClient(apiKey string, defaultEndpointURL string, timeout ...int) (*Client, error) {
+// NewClient returns a new cloudhealth.Client for accessing the CloudHealth API, reaching DefaultEndpointURL
+// with a 15 second timeout unless configured otherwise by opts.
+func NewClient(apiKey string, opts ...ClientOption) (*Client, error) {
 	s := &Client{
-		ApiKey: apiKey,
+		ApiKey:  apiKey,
+		Timeout: defaultTimeout,
 	}
-	endpointURL, err := url.Parse(defaultEndpointURL)
-	if err != nil {
+	if err := WithEndpoint(DefaultEndpointURL)(s); err != nil {
 		return nil, err
 	}
-	s.EndpointURL = endpointURL
-	s.Timeout = defaultTimeout
-	if len(timeout) > 0 {
-		s.Timeout = timeout[0]
+	for _, opt := range opts {
+		if err := opt(s); err != nil {
+			return nil, err
+		}
 	}
 	return s, nil
 }
+
+// httpClient returns the http.Client shared by every request of this Client, built on first use,
+// so connections to CloudHealth are kept alive and reused between calls.
+func (s *Client) httpClient() *http.Client {
+	s.clientOnce.Do(func() {
+		s.client = &http.Client{}
+		if s.baseHTTPClient != nil {
+			*s.client = *s.baseHTTPClient
+		}
+		s.client.Transport = s.newTransport()
+		if s.client.Timeout == 0 {
+			s.client.Timeout = s.timeout()
+		}
+	})
+	return s.client
+}
+
+// timeout returns the Timeout of the Client. Values below a microsecond, left by code written when Timeout was
+// an int of seconds, are still read as seconds.
+func (s *Client) timeout() time.Duration {
+	if s.Timeout > 0 && s.Timeout < time.Microsecond {
+		return s.Timeout * time.Second
+	}
+	return s.Timeout
+}
+
+// newTransport builds the shared transport, layering the optional behaviours enabled on the Client.
+func (s *Client) newTransport() http.RoundTripper {
+	transport := s.baseTransport
+	if transport == nil {
+		transport = s.newHTTPTransport()
+	}
+	s.connTransport = transport
+	if s.Failover != nil {
+		transport = &failoverTransport{next: transport, primary: s.baseURL(), failover: s.Failover, clock: s.clock()}
+	}
+	transport = chainMiddleware(transport, s.Middleware)
+	if s.CircuitBreaker != nil {
+		transport = &breakerTransport{next: transport, breaker: s.CircuitBreaker, clock: s.clock()}
+	}
+	if s.Cache != nil {
+		transport = &cachingTransport{next: transport, cache: s.Cache}
+	}
+	if s.CoalesceGets {
+		transport = newCoalescingTransport(transport)
+	}
+	if s.AuditSink != nil {
+		transport = &auditTransport{next: transport, sink: s.AuditSink, basePath: s.baseURL().Path}
+	}
+	if s.StatsHook != nil {
+		transport = &statsTransport{next: transport, hook: s.StatsHook}
+	}
+	generate := s.RequestIDFunc
+	if generate == nil {
+		generate = newRequestID
+	}
+	transport = &lastResponseTransport{next: transport, client: s}
+	transport = &requestIDTransport{next: transport, generate: generate}
+	return transport
+}
+
+// newHTTPTransport builds the underlying HTTP transport from the Client's tuning fields.
+func (s *Client) newHTTPTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Negotiate HTTP/2 over TLS, multiplexing concurrent requests on a single connection.
+	transport.ForceAttemptHTTP2 = true
+	if s.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = s.MaxIdleConnsPerHost
+	}
+	if s.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = s.IdleConnTimeout
+	}
+	if s.TLSHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = s.TLSHandshakeTimeout
+	}
+	if s.DialTimeout > 0 {
+		transport.DialContext = (&net.Dialer{Timeout: s.DialTimeout, KeepAlive: 30 * time.Second}).DialContext
+	}
+	if s.TLSConfig != nil {
+		transport.TLSClientConfig = s.TLSConfig.Clone()
+	}
+	if s.Proxy != nil {
+		transport.Proxy = s.Proxy
+	}
+	return transport
+}
+
+// SetApiKey replaces the API key used by subsequent requests.
+// Unlike assigning ApiKey, it is safe to call while the Client is in use by other goroutines.
+func (s *Client) SetApiKey(apiKey string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ApiKey = apiKey
+}
+
+// apiKey returns the API key to authenticate the next request with.
+func (s *Client) apiKey() (string, error) {
+	if s.ApiKeyProvider == nil {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		return s.ApiKey, nil
+	}
+	key, err := s.ApiKeyProvider()
+	if err != nil {
+		return "", fmt.Errorf("Unable to get the CloudHealth API key: %w", err)
+	}
+	return key, nil
+}
+
+// baseURL returns the URL API routes are resolved against: the EndpointURL joined with the BasePath,
+// always ending in a slash so that its last path segment is preserved.
+func (s *Client) baseURL() *url.URL {
+	base := *s.EndpointURL
+	p := path.Join("/", base.Path, s.BasePath)
+	if !strings.HasSuffix(p, "/") {
+		p += "/"
+	}
+	base.Path = p
+	base.RawPath = ""
+	return &base
+}
+
+// requestKey identifies the resource and credentials of a request, for sharing responses between identical requests.
+func requestKey(req *http.Request) string {
+	return req.Header.Get("Authorization") + " " + req.URL.String()
+}
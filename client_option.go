@@ -0,0 +1,111 @@
+package cloudhealth
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ClientOption configures a Client created by NewClient.
+type ClientOption func(*Client) error
+
+// WithEndpoint sets the URL of the CloudHealth API, defaulting to DefaultEndpointURL.
+func WithEndpoint(endpointURL string) ClientOption {
+	return func(s *Client) error {
+		u, err := url.Parse(endpointURL)
+		if err != nil {
+			return err
+		}
+		s.EndpointURL = u
+		return nil
+	}
+}
+
+// WithTimeout sets the time limit of requests, defaulting to 15 seconds.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(s *Client) error {
+		s.Timeout = timeout
+		return nil
+	}
+}
+
+// WithHTTPClient makes the Client send requests with a copy of hc, keeping its redirect policy and cookie jar.
+// The Client's optional behaviours are layered around the transport of hc, which replaces the one built from
+// the transport tuning fields. The Client's timeout applies when hc has none.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(s *Client) error {
+		s.baseHTTPClient = hc
+		s.baseTransport = hc.Transport
+		if s.baseTransport == nil {
+			s.baseTransport = http.DefaultTransport
+		}
+		return nil
+	}
+}
+
+// WithTransport makes the Client send requests through transport, which replaces the one built from the
+// transport tuning fields. The Client's optional behaviours are layered around it.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(s *Client) error {
+		s.baseTransport = transport
+		return nil
+	}
+}
+
+// WithTLSConfig sets the TLS configuration of connections to CloudHealth, e.g. RootCAs holding a custom CA bundle.
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(s *Client) error {
+		s.TLSConfig = config
+		return nil
+	}
+}
+
+// WithProxy sends requests through the HTTP proxy at proxyURL instead of the one set in the environment.
+func WithProxy(proxyURL string) ClientOption {
+	return func(s *Client) error {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return err
+		}
+		s.Proxy = http.ProxyURL(u)
+		return nil
+	}
+}
+
+// WithDialTimeout sets the time limit of establishing connections to CloudHealth.
+func WithDialTimeout(timeout time.Duration) ClientOption {
+	return func(s *Client) error {
+		s.DialTimeout = timeout
+		return nil
+	}
+}
+
+// WithRetry retries failed requests according to policy, e.g. NewRetryPolicy(3, time.Second, 30*time.Second).
+func WithRetry(policy *RetryPolicy) ClientOption {
+	return func(s *Client) error {
+		s.Retry = policy
+		return nil
+	}
+}
+
+// WithUserAgent appends userAgent, e.g. "cost-pipeline/1.0", to the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(s *Client) error {
+		s.UserAgent = userAgent
+		return nil
+	}
+}
+
+// WithMiddleware appends middleware to the Client's Middleware, the first one given being the outermost.
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(s *Client) error {
+		s.Middleware = append(s.Middleware, middleware...)
+		return nil
+	}
+}
+
+// WithHooks appends the Middleware calling hooks to the Client's Middleware.
+func WithHooks(hooks Hooks) ClientOption {
+	return WithMiddleware(hooks.Middleware())
+}
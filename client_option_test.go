@@ -0,0 +1,177 @@
+package cloudhealth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewClientDefaults(t *testing.T) {
+	c, err := NewClient("apiKey")
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if c.EndpointURL.String() != DefaultEndpointURL {
+		t.Errorf("NewClient() expected endpoint %s, got %s", DefaultEndpointURL, c.EndpointURL)
+	}
+	if c.Timeout != defaultTimeout {
+		t.Errorf("NewClient() expected timeout %s, got %s", defaultTimeout, c.Timeout)
+	}
+}
+
+func TestWithEndpointInvalid(t *testing.T) {
+	if _, err := NewClient("apiKey", WithEndpoint("://invalid")); err == nil {
+		t.Errorf("NewClient() expected an error for an invalid endpoint")
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	c, err := NewClient("apiKey", WithTimeout(1500*time.Millisecond))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if timeout := c.httpClient().Timeout; timeout != 1500*time.Millisecond {
+		t.Errorf("WithTimeout() expected a timeout of 1.5s, got %s", timeout)
+	}
+}
+
+func TestTimeoutInSeconds(t *testing.T) {
+	c, err := NewClient("apiKey")
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.Timeout = 30
+	if timeout := c.httpClient().Timeout; timeout != 30*time.Second {
+		t.Errorf("Expected a Timeout of 30 to be read as seconds, got %s", timeout)
+	}
+}
+
+func TestWithHTTPClientAndUserAgent(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ua := r.Header.Get("User-Agent"); ua != "cloudhealth-sdk-go/"+Version+" cost-pipeline/1.0" {
+			t.Errorf("Expected User-Agent ‘cloudhealth-sdk-go/%s cost-pipeline/1.0’, got ‘%s’", Version, ua)
+		}
+		if r.Header.Get("X-Custom") != "set" {
+			t.Errorf("Expected the request to go through the given client's transport")
+		}
+		w.Write([]byte(`{"generated_external_id":"externalid"}`))
+	}))
+	defer ts.Close()
+
+	hc := &http.Client{
+		Timeout: time.Minute,
+		Transport: RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Custom", "set")
+			return http.DefaultTransport.RoundTrip(req)
+		}),
+	}
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL), WithHTTPClient(hc), WithUserAgent("cost-pipeline/1.0"))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if timeout := c.httpClient().Timeout; timeout != time.Minute {
+		t.Errorf("Expected the given client's timeout, got %s", timeout)
+	}
+	if _, err := c.GetAwsExternalID(); err != nil {
+		t.Errorf("GetAwsExternalID() returned an error: %s", err)
+	}
+}
+
+func TestWithTransport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Custom") != "set" {
+			t.Errorf("Expected the request to go through the given transport")
+		}
+		w.Write([]byte(`{"generated_external_id":"externalid"}`))
+	}))
+	defer ts.Close()
+
+	transport := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		req.Header.Set("X-Custom", "set")
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL), WithTransport(transport))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if _, err := c.GetAwsExternalID(); err != nil {
+		t.Errorf("GetAwsExternalID() returned an error: %s", err)
+	}
+}
+
+func TestWithTLSConfig(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"generated_external_id":"externalid"}`))
+	}))
+	defer ts.Close()
+
+	untrusted, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if _, err := untrusted.GetAwsExternalID(); err == nil {
+		t.Errorf("Expected an error for a server certificate signed by an unknown authority")
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ts.Certificate())
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL), WithTLSConfig(&tls.Config{RootCAs: roots}))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if _, err := c.GetAwsExternalID(); err != nil {
+		t.Errorf("GetAwsExternalID() returned an error: %s", err)
+	}
+}
+
+func TestWithProxy(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host != "chapi.example.com" {
+			t.Errorf("Expected the proxy to be asked for chapi.example.com, got ‘%s’", r.URL.Host)
+		}
+		w.Write([]byte(`{"generated_external_id":"externalid"}`))
+	}))
+	defer proxy.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint("http://chapi.example.com/v1/"), WithProxy(proxy.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if _, err := c.GetAwsExternalID(); err != nil {
+		t.Errorf("GetAwsExternalID() returned an error: %s", err)
+	}
+}
+
+func TestWithDialTimeout(t *testing.T) {
+	c, err := NewClient("apiKey", WithDialTimeout(2*time.Second))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if c.DialTimeout != 2*time.Second {
+		t.Errorf("WithDialTimeout() expected a dial timeout of 2s, got %s", c.DialTimeout)
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	policy := NewRetryPolicy(3, time.Second, time.Minute)
+	c, err := NewClient("apiKey", WithRetry(policy))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if c.Retry != policy {
+		t.Errorf("WithRetry() expected the Client to use the policy, got %+v", c.Retry)
+	}
+}
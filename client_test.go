@@ -1,10 +1,16 @@
 package cloudhealth
 
 import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestBadApiKey(t *testing.T) {
@@ -20,41 +26,256 @@ func TestBadApiKey(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	c, err := NewClient("apiKey", ts.URL)
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
 	if err != nil {
 		t.Errorf("NewClient() returned an error: %s", err)
 		return
 	}
 
 	_, err = c.GetAwsExternalID()
-	if err != ErrClientAuthenticationError {
+	if !errors.Is(err, ErrClientAuthenticationError) {
 		t.Errorf("GetAwsExternalID() returned the wrong error: %s", err)
 		return
 	}
 }
 
 func TestTimeoutArg(t *testing.T) {
-	testTimeout := 42
-	c, err := NewClient("apiKey", "https://api.foo.bar", testTimeout)
+	testTimeout := 42 * time.Second
+	c, err := NewClient("apiKey", WithEndpoint("https://api.foo.bar"), WithTimeout(testTimeout))
 	if err != nil {
 		t.Errorf("NewClient() returned an error: %s", err)
 		return
 	}
 	if c.Timeout != testTimeout {
-		t.Errorf("Unexpected NewClient() Timeout value: %d != %d", c.Timeout, testTimeout)
+		t.Errorf("Unexpected NewClient() Timeout value: %s != %s", c.Timeout, testTimeout)
 		return
 	}
 }
 
 func TestDefaultTimeout(t *testing.T) {
-	defaultTimeout := 15
-	c, err := NewClient("apiKey", "https://api.foo.bar")
+	defaultTimeout := 15 * time.Second
+	c, err := NewClient("apiKey", WithEndpoint("https://api.foo.bar"))
 	if err != nil {
 		t.Errorf("NewClient() returned an error: %s", err)
 		return
 	}
 	if c.Timeout != defaultTimeout {
-		t.Errorf("Unexpected NewClient() Timeout value: %d != %d", c.Timeout, defaultTimeout)
+		t.Errorf("Unexpected NewClient() Timeout value: %s != %s", c.Timeout, defaultTimeout)
 		return
 	}
 }
+
+func TestGzipResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			t.Errorf("Expected request to accept gzip encoding, got ‘%s’", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusOK)
+		gz := gzip.NewWriter(w)
+		body, _ := json.Marshal(defaultAWSAccount)
+		gz.Write(body)
+		gz.Close()
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	returnedAwsAccount, err := c.GetAwsAccount(defaultAWSAccount.ID)
+	if err != nil {
+		t.Errorf("GetAwsAccount() returned an error: %s", err)
+		return
+	}
+	if returnedAwsAccount.Name != defaultAWSAccount.Name {
+		t.Errorf("GetAwsAccount() expected Name `%s`, got `%s`", defaultAWSAccount.Name, returnedAwsAccount.Name)
+		return
+	}
+}
+
+func TestTransportTuning(t *testing.T) {
+	c, err := NewClient("apiKey", WithEndpoint("https://api.foo.bar"))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.MaxIdleConnsPerHost = 32
+	c.IdleConnTimeout = 2 * time.Minute
+	c.TLSHandshakeTimeout = 3 * time.Second
+
+	transport := c.newHTTPTransport()
+	if transport.MaxIdleConnsPerHost != c.MaxIdleConnsPerHost {
+		t.Errorf("Unexpected MaxIdleConnsPerHost value: %d != %d", transport.MaxIdleConnsPerHost, c.MaxIdleConnsPerHost)
+	}
+	if transport.IdleConnTimeout != c.IdleConnTimeout {
+		t.Errorf("Unexpected IdleConnTimeout value: %s != %s", transport.IdleConnTimeout, c.IdleConnTimeout)
+	}
+	if transport.TLSHandshakeTimeout != c.TLSHandshakeTimeout {
+		t.Errorf("Unexpected TLSHandshakeTimeout value: %s != %s", transport.TLSHandshakeTimeout, c.TLSHandshakeTimeout)
+	}
+	if c.httpClient() != c.httpClient() {
+		t.Errorf("Expected the HTTP client to be shared between requests")
+	}
+}
+
+func TestBasePath(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedURL := "/cloudhealth/v1/aws_accounts/:id/generate_external_id"
+		if r.URL.EscapedPath() != expectedURL {
+			t.Errorf("Expected request to ‘%s’, got ‘%s’", expectedURL, r.URL.EscapedPath())
+		}
+		w.Write([]byte(`{"generated_external_id":"externalid"}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.BasePath = "/cloudhealth/v1"
+
+	if _, err := c.GetAwsExternalID(); err != nil {
+		t.Errorf("GetAwsExternalID() returned an error: %s", err)
+	}
+}
+
+func TestEndpointURLPathPreserved(t *testing.T) {
+	for _, endpoint := range []string{"https://gateway.example.com/cloudhealth/v1", "https://gateway.example.com/cloudhealth/v1/"} {
+		c, err := NewClient("apiKey", WithEndpoint(endpoint))
+		if err != nil {
+			t.Errorf("NewClient() returned an error: %s", err)
+			return
+		}
+		u := c.url(apiCall{path: "aws_accounts/1"})
+		if u.Path != "/cloudhealth/v1/aws_accounts/1" {
+			t.Errorf("url() for endpoint %s expected path ‘/cloudhealth/v1/aws_accounts/1’, got ‘%s’", endpoint, u.Path)
+		}
+	}
+}
+
+func TestApiKeyProvider(t *testing.T) {
+	var keys []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, bearerToken(r))
+		w.Write([]byte(`{"generated_external_id":"externalid"}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("staticKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	rotations := 0
+	c.ApiKeyProvider = func() (string, error) {
+		rotations++
+		return fmt.Sprintf("rotatedKey%d", rotations), nil
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetAwsExternalID(); err != nil {
+			t.Errorf("GetAwsExternalID() returned an error: %s", err)
+			return
+		}
+	}
+	if len(keys) != 2 || keys[0] != "rotatedKey1" || keys[1] != "rotatedKey2" {
+		t.Errorf("Expected requests to use the provided keys, got %v", keys)
+	}
+}
+
+func TestApiKeyProviderError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request when the API key provider fails")
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	errVault := errors.New("vault sealed")
+	c.ApiKeyProvider = func() (string, error) {
+		return "", errVault
+	}
+
+	if _, err := c.GetAwsExternalID(); !errors.Is(err, errVault) {
+		t.Errorf("GetAwsExternalID() expected the provider error, got %v", err)
+	}
+}
+
+func TestSetApiKey(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key := bearerToken(r); key != "oldKey" && key != "newKey" {
+			t.Errorf("Expected request with either API key, got ‘%s’", key)
+		}
+		w.Write([]byte(`{"generated_external_id":"externalid"}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("oldKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetAwsExternalID(); err != nil {
+				t.Errorf("GetAwsExternalID() returned an error: %s", err)
+			}
+		}()
+	}
+	c.SetApiKey("newKey")
+	wg.Wait()
+
+	if key, _ := c.apiKey(); key != "newKey" {
+		t.Errorf("SetApiKey() expected API key ‘newKey’, got ‘%s’", key)
+	}
+}
+
+// benchmarkGetAwsAccount gets an AWS Account from a TLS server b.N times with the Client returned by client.
+func benchmarkGetAwsAccount(b *testing.B, client func(endpoint string) *Client) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":1,"name":"test"}`))
+	}))
+	defer ts.Close()
+	trusted := ts.Client().Transport.(*http.Transport).TLSClientConfig
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c := client(ts.URL)
+		if c.baseTransport == nil {
+			transport := c.newHTTPTransport()
+			transport.TLSClientConfig = trusted
+			c.baseTransport = transport
+		}
+		if _, err := c.GetAwsAccount(1); err != nil {
+			b.Fatalf("GetAwsAccount() returned an error: %s", err)
+		}
+	}
+}
+
+func BenchmarkSharedHTTPClient(b *testing.B) {
+	var shared *Client
+	benchmarkGetAwsAccount(b, func(endpoint string) *Client {
+		if shared == nil {
+			shared, _ = NewClient("apiKey", WithEndpoint(endpoint))
+		}
+		return shared
+	})
+}
+
+func BenchmarkHTTPClientPerRequest(b *testing.B) {
+	benchmarkGetAwsAccount(b, func(endpoint string) *Client {
+		c, _ := NewClient("apiKey", WithEndpoint(endpoint))
+		return c
+	})
+}
@@ -0,0 +1,40 @@
+package cloudhealth
+
+import (
+	"context"
+	"time"
+)
+
+// Clock is the source of time for the Client's timing logic, such as the circuit breaker cool-down
+// and waits between retries. Tests can inject a fake Clock so this logic runs instantly and deterministically.
+type Clock interface {
+	Now() time.Time
+	// Sleep waits for d, returning early with the context's error if ctx is done first.
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// realClock is the Clock backed by the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// clock returns the Client's Clock, defaulting to the real time.
+func (s *Client) clock() Clock {
+	if s.Clock != nil {
+		return s.Clock
+	}
+	return realClock{}
+}
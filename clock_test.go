@@ -0,0 +1,80 @@
+package cloudhealth
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock whose time only moves when slept on or advanced.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(1586736000, 0)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.Advance(d)
+	c.mu.Lock()
+	c.sleeps = append(c.sleeps, d)
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestRealClockSleepCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := (realClock{}).Sleep(ctx, time.Hour); err != context.Canceled {
+		t.Errorf("Sleep() returned the wrong error: %v", err)
+	}
+}
+
+func TestClientClockUsedByCircuitBreaker(t *testing.T) {
+	requests := 0
+	next := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		requests++
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(strings.NewReader(""))}, nil
+	})
+	c, err := NewClient("apiKey", WithEndpoint("https://api.foo.bar"), WithTransport(next))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	clock := newFakeClock()
+	c.Clock = clock
+	c.CircuitBreaker = NewCircuitBreaker(1, time.Minute)
+
+	c.GetAwsExternalID()
+	if _, err := c.GetAwsExternalID(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected the circuit breaker to be open, got %v", err)
+	}
+	clock.Advance(time.Minute)
+	c.GetAwsExternalID()
+	if requests != 2 {
+		t.Errorf("Expected the circuit breaker cool-down to follow the Client's Clock, got %d requests", requests)
+	}
+}
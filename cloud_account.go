@@ -0,0 +1,53 @@
+package cloudhealth
+
+// Cloud identifies the cloud provider of a CloudAccount.
+type Cloud string
+
+// Cloud providers.
+const (
+	CloudAWS Cloud = "aws"
+)
+
+// CloudAccount is an account of any cloud enabled in CloudHealth, letting tooling such as health checks,
+// reporting and reconciliation work regardless of the provider. AwsAccount implements it; Azure subscriptions
+// and GCP projects are to implement it once supported.
+type CloudAccount interface {
+	Cloud() Cloud
+	// CloudHealthID is the ID CloudHealth assigned to the account.
+	CloudHealthID() int
+	// NativeID is the ID of the account at the provider, such as the 12-digit AWS account ID, when known.
+	NativeID() string
+	DisplayName() string
+	// HealthLevel is the status level reported by CloudHealth, e.g. "green", or empty when not reported.
+	HealthLevel() string
+}
+
+var _ CloudAccount = AwsAccount{}
+
+// Cloud implements CloudAccount.
+func (a AwsAccount) Cloud() Cloud {
+	return CloudAWS
+}
+
+// CloudHealthID implements CloudAccount.
+func (a AwsAccount) CloudHealthID() int {
+	return a.ID
+}
+
+// NativeID implements CloudAccount, returning the OwnerID.
+func (a AwsAccount) NativeID() string {
+	return a.OwnerID
+}
+
+// DisplayName implements CloudAccount.
+func (a AwsAccount) DisplayName() string {
+	return a.Name
+}
+
+// HealthLevel implements CloudAccount.
+func (a AwsAccount) HealthLevel() string {
+	if a.Status == nil {
+		return ""
+	}
+	return a.Status.Level
+}
@@ -0,0 +1,15 @@
+package cloudhealth
+
+import "testing"
+
+func TestAwsAccountCloudAccount(t *testing.T) {
+	var account CloudAccount = AwsAccount{ID: 1, Name: "Production", OwnerID: "123456789012", Status: &AwsAccountStatus{Level: "green"}}
+	if account.Cloud() != CloudAWS || account.CloudHealthID() != 1 || account.NativeID() != "123456789012" ||
+		account.DisplayName() != "Production" || account.HealthLevel() != "green" {
+		t.Errorf("AwsAccount returned unexpected CloudAccount values: %s %d %s %s %s",
+			account.Cloud(), account.CloudHealthID(), account.NativeID(), account.DisplayName(), account.HealthLevel())
+	}
+	if level := (AwsAccount{}).HealthLevel(); level != "" {
+		t.Errorf("HealthLevel() expected no level without a status, got %s", level)
+	}
+}
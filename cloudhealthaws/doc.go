@@ -0,0 +1,17 @@
+// Package cloudhealthaws provides API key providers for cloudhealth.Client that read the CloudHealth API key
+// from AWS Secrets Manager or SSM Parameter Store.
+//
+// The providers call AWS through a client given by the caller, usually a thin adapter around the clients of
+// aws-sdk-go-v2, so credentials are resolved by the AWS SDK from task roles, instance profiles, IRSA or shared
+// config profiles, and the SDK doesn't depend on the AWS SDK itself:
+//
+//	type secretsManager struct{ *secretsmanager.Client }
+//
+//	func (c secretsManager) GetSecretValue(ctx context.Context, secretID string) (string, error) {
+//		out, err := c.Client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: &secretID})
+//		if err != nil {
+//			return "", err
+//		}
+//		return aws.ToString(out.SecretString), nil
+//	}
+package cloudhealthaws
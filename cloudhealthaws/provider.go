@@ -0,0 +1,90 @@
+package cloudhealthaws
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTTL is how long a fetched API key is reused before it is read again from AWS.
+const DefaultTTL = 5 * time.Minute
+
+// DefaultTimeout is the time limit of reading the API key from AWS.
+const DefaultTimeout = 10 * time.Second
+
+// SecretsManagerClient reads secrets from AWS Secrets Manager, e.g. by calling GetSecretValue
+// of an aws-sdk-go-v2 secretsmanager.Client.
+type SecretsManagerClient interface {
+	// GetSecretValue returns the SecretString of the secret secretID.
+	GetSecretValue(ctx context.Context, secretID string) (string, error)
+}
+
+// SSMClient reads parameters from AWS Systems Manager Parameter Store, e.g. by calling GetParameter
+// of an aws-sdk-go-v2 ssm.Client.
+type SSMClient interface {
+	// GetParameter returns the value of the parameter name, decrypted when it is a SecureString.
+	GetParameter(ctx context.Context, name string) (string, error)
+}
+
+// Config tunes the caching and time limit of a provider. The zero value uses DefaultTTL and DefaultTimeout.
+type Config struct {
+	// TTL is how long a fetched API key is reused. Defaults to DefaultTTL.
+	TTL time.Duration
+	// Timeout is the time limit of each read from AWS. Defaults to DefaultTimeout.
+	Timeout time.Duration
+
+	now func() time.Time
+}
+
+// SecretsManager returns an API key provider, for cloudhealth.Client.ApiKeyProvider, reading the
+// SecretString of secretID from AWS Secrets Manager with client.
+func SecretsManager(client SecretsManagerClient, secretID string, cfg Config) func() (string, error) {
+	return cfg.cached(func(ctx context.Context) (string, error) {
+		return client.GetSecretValue(ctx, secretID)
+	})
+}
+
+// SSMParameter returns an API key provider, for cloudhealth.Client.ApiKeyProvider, reading the
+// decrypted value of the SSM Parameter Store parameter name with client.
+func SSMParameter(client SSMClient, name string, cfg Config) func() (string, error) {
+	return cfg.cached(func(ctx context.Context) (string, error) {
+		return client.GetParameter(ctx, name)
+	})
+}
+
+// cached reuses the key returned by fetch for the TTL of the Config, bounding each fetch by its Timeout.
+func (cfg Config) cached(fetch func(ctx context.Context) (string, error)) func() (string, error) {
+	ttl := cfg.TTL
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	now := cfg.now
+	if now == nil {
+		now = time.Now
+	}
+
+	var mu sync.Mutex
+	var key string
+	var expires time.Time
+	return func() (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if key != "" && now().Before(expires) {
+			return key, nil
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		fetched, err := fetch(ctx)
+		if err != nil {
+			return "", err
+		}
+		key = strings.TrimSpace(fetched)
+		expires = now().Add(ttl)
+		return key, nil
+	}
+}
@@ -0,0 +1,82 @@
+package cloudhealthaws
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeSecretsManager serves secrets from a map, counting its calls.
+type fakeSecretsManager struct {
+	secrets map[string]string
+	calls   int
+}
+
+func (f *fakeSecretsManager) GetSecretValue(ctx context.Context, secretID string) (string, error) {
+	f.calls++
+	secret, ok := f.secrets[secretID]
+	if !ok {
+		return "", errors.New("ResourceNotFoundException")
+	}
+	return secret, nil
+}
+
+// ssmFunc adapts a function to SSMClient.
+type ssmFunc func(ctx context.Context, name string) (string, error)
+
+func (f ssmFunc) GetParameter(ctx context.Context, name string) (string, error) {
+	return f(ctx, name)
+}
+
+func TestSecretsManager(t *testing.T) {
+	client := &fakeSecretsManager{secrets: map[string]string{"cloudhealth/api-key": "apiKey\n"}}
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	provider := SecretsManager(client, "cloudhealth/api-key", Config{now: func() time.Time { return now }})
+
+	for i := 0; i < 2; i++ {
+		if key, err := provider(); err != nil || key != "apiKey" {
+			t.Errorf("provider() expected ‘apiKey’, got ‘%s’, %v", key, err)
+			return
+		}
+	}
+	if client.calls != 1 {
+		t.Errorf("Expected the key to be cached, got %d calls", client.calls)
+	}
+
+	now = now.Add(DefaultTTL)
+	provider()
+	if client.calls != 2 {
+		t.Errorf("Expected the key to be fetched again after the TTL, got %d calls", client.calls)
+	}
+}
+
+func TestSSMParameter(t *testing.T) {
+	client := ssmFunc(func(ctx context.Context, name string) (string, error) {
+		if name != "/cloudhealth/api-key" {
+			t.Errorf("Expected parameter ‘/cloudhealth/api-key’, got ‘%s’", name)
+		}
+		return "apiKey", nil
+	})
+	if key, err := SSMParameter(client, "/cloudhealth/api-key", Config{})(); err != nil || key != "apiKey" {
+		t.Errorf("provider() expected ‘apiKey’, got ‘%s’, %v", key, err)
+	}
+}
+
+func TestProviderError(t *testing.T) {
+	_, err := SecretsManager(&fakeSecretsManager{}, "missing", Config{})()
+	if err == nil || err.Error() != "ResourceNotFoundException" {
+		t.Errorf("provider() expected the AWS error, got %v", err)
+	}
+}
+
+func TestProviderTimeout(t *testing.T) {
+	client := ssmFunc(func(ctx context.Context, name string) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	_, err := SSMParameter(client, "/cloudhealth/api-key", Config{Timeout: 10 * time.Millisecond})()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("provider() expected context.DeadlineExceeded, got %v", err)
+	}
+}
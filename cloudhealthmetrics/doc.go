@@ -0,0 +1,15 @@
+// Package cloudhealthmetrics exports CloudHealth costs as metrics, so spend can be graphed next to operational
+// metrics.
+//
+// An Exporter periodically pulls the month-to-date cost of selected reports with a cloudhealth.Client and records
+// each group's cost to a CostCollector. Gauges is a CostCollector serving the costs to Prometheus:
+//
+//	gauges := new(cloudhealthmetrics.Gauges)
+//	exporter := &cloudhealthmetrics.Exporter{
+//		Client:    client,
+//		Reports:   []cloudhealthmetrics.Report{{Name: "environment", PerspectiveID: "1649267441721"}},
+//		Collector: gauges,
+//	}
+//	go exporter.Run(ctx)
+//	http.Handle("/metrics", gauges)
+package cloudhealthmetrics
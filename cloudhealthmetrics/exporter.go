@@ -0,0 +1,166 @@
+package cloudhealthmetrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+)
+
+// DefaultInterval is how often an Exporter pulls its reports when no Interval is set. Cost data changes slowly,
+// so pulling more often mostly spends the tenant's API rate limit.
+const DefaultInterval = time.Hour
+
+// Report is a cost report pulled by an Exporter, grouping costs by the groups of a Perspective or by the values
+// of an AWS tag key.
+type Report struct {
+	Name          string // identifies the report in the metrics
+	PerspectiveID string // groups costs by the groups of the Perspective
+	TagKey        string // groups costs by the values of the AWS tag key, when PerspectiveID is empty
+	Basis         cloudhealth.CostBasis
+	OrgID         string
+}
+
+// CostCollector records the costs pulled by an Exporter, such as Prometheus gauges, as
+// cloudhealth.MetricsCollector does for requests.
+type CostCollector interface {
+	SetCost(report, group string, cost float64)
+}
+
+// Exporter periodically pulls the month-to-date cost of its Reports and records them to its Collector.
+type Exporter struct {
+	Client    *cloudhealth.Client
+	Reports   []Report
+	Collector CostCollector
+	Interval  time.Duration // defaults to DefaultInterval
+	// OnError, when set, is called with the error of every report that couldn't be pulled.
+	OnError func(report Report, err error)
+}
+
+// Collect pulls every report once, recording the cost of each of its groups since the start of the month. Reports
+// failing don't stop the others, the first error being returned.
+func (e *Exporter) Collect(ctx context.Context) error {
+	now := e.now()
+	q := cloudhealth.CostQuery{From: time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()), To: now}
+	var first error
+	for _, report := range e.Reports {
+		q.Basis, q.OrgID = report.Basis, report.OrgID
+		costs, err := report.costs(e.Client, q, cloudhealth.WithContext(ctx))
+		if err != nil {
+			if e.OnError != nil {
+				e.OnError(report, err)
+			}
+			if first == nil {
+				first = err
+			}
+			continue
+		}
+		for _, cost := range costs {
+			e.Collector.SetCost(report.Name, cost.Name, cost.Cost)
+		}
+	}
+	return first
+}
+
+// Run collects the reports at once and then every Interval, until ctx is done. It returns the context's error.
+func (e *Exporter) Run(ctx context.Context) error {
+	interval := e.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	for {
+		e.Collect(ctx)
+		if err := e.sleep(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// now returns the time of the Client's Clock.
+func (e *Exporter) now() time.Time {
+	if e.Client.Clock != nil {
+		return e.Client.Clock.Now()
+	}
+	return time.Now()
+}
+
+// sleep waits for d with the Client's Clock, returning early with the context's error if ctx is done first.
+func (e *Exporter) sleep(ctx context.Context, d time.Duration) error {
+	if e.Client.Clock != nil {
+		return e.Client.Clock.Sleep(ctx, d)
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r Report) costs(client *cloudhealth.Client, q cloudhealth.CostQuery, opts ...cloudhealth.CallOption) ([]cloudhealth.GroupCost, error) {
+	if r.PerspectiveID != "" {
+		return client.CostByPerspectiveGroup(r.PerspectiveID, q, opts...)
+	}
+	return client.CostByTag(r.TagKey, q, opts...)
+}
+
+// Gauges is a CostCollector keeping the last cost of each report group, served to Prometheus in its text
+// exposition format as the cloudhealth_month_to_date_cost gauge. The zero value is ready to use.
+type Gauges struct {
+	mu    sync.Mutex
+	costs map[gauge]float64
+}
+
+type gauge struct {
+	report, group string
+}
+
+// SetCost implements CostCollector.
+func (g *Gauges) SetCost(report, group string, cost float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.costs == nil {
+		g.costs = make(map[gauge]float64)
+	}
+	g.costs[gauge{report, group}] = cost
+}
+
+// ServeHTTP implements http.Handler, writing the gauges sorted by report and group.
+func (g *Gauges) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	costs := make(map[gauge]float64, len(g.costs))
+	gauges := make([]gauge, 0, len(g.costs))
+	for key, cost := range g.costs {
+		costs[key] = cost
+		gauges = append(gauges, key)
+	}
+	g.mu.Unlock()
+	sort.Slice(gauges, func(i, j int) bool {
+		if gauges[i].report != gauges[j].report {
+			return gauges[i].report < gauges[j].report
+		}
+		return gauges[i].group < gauges[j].group
+	})
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprintln(w, "# HELP cloudhealth_month_to_date_cost Cost of a CloudHealth report group since the start of the month.")
+	fmt.Fprintln(w, "# TYPE cloudhealth_month_to_date_cost gauge")
+	for _, key := range gauges {
+		fmt.Fprintf(w, "cloudhealth_month_to_date_cost{report=\"%s\",group=\"%s\"} %g\n",
+			labelValue(key.report), labelValue(key.group), costs[key])
+	}
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// labelValue escapes value for a label of the Prometheus text exposition format.
+func labelValue(value string) string {
+	return labelEscaper.Replace(value)
+}
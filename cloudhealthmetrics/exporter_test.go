@@ -0,0 +1,94 @@
+package cloudhealthmetrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go/cloudhealthtest"
+)
+
+// cancelingClock is a Clock stopped at now, canceling the context of Run when slept on.
+type cancelingClock struct {
+	now    time.Time
+	cancel context.CancelFunc
+}
+
+func (c cancelingClock) Now() time.Time {
+	return c.now
+}
+
+func (c cancelingClock) Sleep(ctx context.Context, d time.Duration) error {
+	c.cancel()
+	return ctx.Err()
+}
+
+func TestExporterRun(t *testing.T) {
+	fixtures := cloudhealthtest.Handler()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/olap_reports/cost/history" {
+			if filter := r.URL.Query().Get("filters[]"); filter != "time:select:2022-04" {
+				t.Errorf("Expected the report of the current month, got the filter ‘%s’", filter)
+			}
+			if r.URL.Query()["dimensions[]"][1] == "AWS-Tag-team" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+		fixtures.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	client, err := cloudhealth.NewClient("apiKey", cloudhealth.WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client.Clock = cancelingClock{now: time.Date(2022, 4, 12, 0, 0, 0, 0, time.UTC), cancel: cancel}
+
+	gauges := new(Gauges)
+	var failed []string
+	exporter := &Exporter{
+		Client:    client,
+		Reports:   []Report{{Name: "environment", PerspectiveID: "1649267441721"}, {Name: "team", TagKey: "team"}},
+		Collector: gauges,
+		OnError:   func(report Report, err error) { failed = append(failed, report.Name) },
+	}
+	if err := exporter.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() expected context.Canceled, got %v", err)
+	}
+	if len(failed) != 1 || failed[0] != "team" {
+		t.Errorf("Expected the team report to fail, got failures of %v", failed)
+	}
+
+	rec := httptest.NewRecorder()
+	gauges.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+	for _, line := range []string{
+		"# TYPE cloudhealth_month_to_date_cost gauge",
+		`cloudhealth_month_to_date_cost{report="environment",group="Production"} 18342.17`,
+		`cloudhealth_month_to_date_cost{report="environment",group="Other"} 3250.7`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Expected the metrics to contain %s, got:\n%s", line, body)
+		}
+	}
+}
+
+func TestGaugesEscapeLabels(t *testing.T) {
+	gauges := new(Gauges)
+	gauges.SetCost("teams", `a "quoted"\name`, 1.5)
+
+	rec := httptest.NewRecorder()
+	gauges.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if expected := `cloudhealth_month_to_date_cost{report="teams",group="a \"quoted\"\\name"} 1.5`; !strings.Contains(rec.Body.String(), expected) {
+		t.Errorf("Expected the metrics to contain %s, got:\n%s", expected, rec.Body.String())
+	}
+}
@@ -0,0 +1,34 @@
+package cloudhealthtest
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+)
+
+var _ cloudhealth.Clock = (*FakeClock)(nil)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Unix(1586736000, 0)
+	clock := NewFakeClock(start)
+
+	if err := clock.Sleep(context.Background(), time.Second); err != nil {
+		t.Errorf("Sleep() returned an error: %s", err)
+	}
+	clock.Advance(time.Minute)
+	if !clock.Now().Equal(start.Add(time.Minute + time.Second)) {
+		t.Errorf("Unexpected Now(): %s", clock.Now())
+	}
+	if !reflect.DeepEqual(clock.Sleeps(), []time.Duration{time.Second}) {
+		t.Errorf("Unexpected Sleeps(): %v", clock.Sleeps())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := clock.Sleep(ctx, time.Second); err != context.Canceled {
+		t.Errorf("Sleep() returned the wrong error: %v", err)
+	}
+}
@@ -0,0 +1,2 @@
+// Package cloudhealthtest provides utilities for testing code built on the CloudHealth SDK without live credentials.
+package cloudhealthtest
@@ -0,0 +1,97 @@
+package cloudhealthtest
+
+import (
+	"embed"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Names of the golden response fixtures, as returned by the CloudHealth API. Statements have no fixture, as the
+// SDK doesn't cover their endpoints yet.
+const (
+	FixtureAwsAccounts        = "aws_accounts.json"
+	FixtureAwsAccount         = "aws_account.json"
+	FixtureAwsExternalID      = "aws_external_id.json"
+	FixturePerspectiveSchemas = "perspective_schemas.json"
+	FixturePerspectiveSchema  = "perspective_schema.json"
+	FixtureCostHistoryReport  = "cost_history_report.json"
+)
+
+// Bodies of the listing pages past the last one.
+const (
+	emptyAwsAccountsPage  = `{"aws_accounts":[]}`
+	emptyPerspectivesPage = `{}`
+)
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+// Fixture returns the golden response body with the given name. It panics if the fixture doesn't exist.
+func Fixture(name string) []byte {
+	data, err := fixtures.ReadFile(path.Join("fixtures", name))
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// ServeFixture returns an http.Handler responding with the named fixture and status code.
+func ServeFixture(name string, statusCode int) http.Handler {
+	body := Fixture(name)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		w.Write(body)
+	})
+}
+
+// servePage returns an http.Handler responding with the named listing fixture as its first page, and with the
+// empty body for later pages.
+func servePage(name, empty string) http.Handler {
+	first := ServeFixture(name, http.StatusOK)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if page, _ := strconv.Atoi(r.URL.Query().Get("page")); page > 1 {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(empty))
+			return
+		}
+		first.ServeHTTP(w, r)
+	})
+}
+
+// Handler returns an http.Handler serving the golden fixtures for the read endpoints of the CloudHealth API.
+// Listings fit in their first page. Any single AWS Account or Perspective ID is answered with the same fixture,
+// as is any cost history report query.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			http.Error(w, "Method not supported by fixtures", http.StatusMethodNotAllowed)
+			return
+		}
+		p := strings.Trim(r.URL.EscapedPath(), "/")
+		switch {
+		case p == "aws_accounts":
+			servePage(FixtureAwsAccounts, emptyAwsAccountsPage).ServeHTTP(w, r)
+		case p == "aws_accounts/:id/generate_external_id":
+			ServeFixture(FixtureAwsExternalID, http.StatusOK).ServeHTTP(w, r)
+		case strings.HasPrefix(p, "aws_accounts/"):
+			ServeFixture(FixtureAwsAccount, http.StatusOK).ServeHTTP(w, r)
+		case p == "perspective_schemas":
+			servePage(FixturePerspectiveSchemas, emptyPerspectivesPage).ServeHTTP(w, r)
+		case strings.HasPrefix(p, "perspective_schemas/"):
+			ServeFixture(FixturePerspectiveSchema, http.StatusOK).ServeHTTP(w, r)
+		case p == "olap_reports/cost/history":
+			ServeFixture(FixtureCostHistoryReport, http.StatusOK).ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// NewServer starts an httptest.Server serving the golden fixtures. The caller should Close it when finished.
+func NewServer() *httptest.Server {
+	return httptest.NewServer(Handler())
+}
@@ -0,0 +1,115 @@
+package cloudhealthtest
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+)
+
+func TestFixturesServer(t *testing.T) {
+	ts := NewServer()
+	defer ts.Close()
+
+	c, err := cloudhealth.NewClient("apiKey", cloudhealth.WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	accounts, err := c.GetAllAwsAccounts(100)
+	if err != nil {
+		t.Errorf("GetAllAwsAccounts() returned an error: %s", err)
+		return
+	}
+	if len(accounts) != 2 || accounts[0].Authentication.Protocol != "assume_role" {
+		t.Errorf("GetAllAwsAccounts() returned unexpected accounts: %+v", accounts)
+	}
+
+	perspectives, err := c.GetAllPerspectives()
+	if err != nil {
+		t.Errorf("GetAllPerspectives() returned an error: %s", err)
+		return
+	}
+	for id := range *perspectives {
+		perspective, err := c.GetPerspective(id)
+		if err != nil {
+			t.Errorf("GetPerspective() returned an error: %s", err)
+			return
+		}
+		if len(perspective.Schema.Rules) == 0 || len(perspective.Schema.Constants) == 0 {
+			t.Errorf("GetPerspective() returned an unexpected schema: %+v", perspective.Schema)
+		}
+	}
+
+	if _, err := c.GetAwsExternalID(); err != nil {
+		t.Errorf("GetAwsExternalID() returned an error: %s", err)
+	}
+}
+
+func TestFixturesServerPages(t *testing.T) {
+	ts := NewServer()
+	defer ts.Close()
+
+	c, err := cloudhealth.NewClient("apiKey", cloudhealth.WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	accounts, err := c.GetAllAwsAccounts(2, cloudhealth.WithContext(ctx))
+	if err != nil {
+		t.Errorf("GetAllAwsAccounts() returned an error: %s", err)
+		return
+	}
+	if len(accounts) != 2 {
+		t.Errorf("GetAllAwsAccounts() returned %d accounts, expected 2", len(accounts))
+	}
+
+	pages := 0
+	err = c.GetPerspectivesPages(1, func(page cloudhealth.PerspectiveMap) bool {
+		pages++
+		return true
+	}, cloudhealth.WithContext(ctx))
+	if err != nil {
+		t.Errorf("GetPerspectivesPages() returned an error: %s", err)
+		return
+	}
+	if pages != 1 {
+		t.Errorf("GetPerspectivesPages() returned %d pages, expected 1", pages)
+	}
+}
+
+func TestFixturesServerCostReport(t *testing.T) {
+	ts := NewServer()
+	defer ts.Close()
+
+	c, err := cloudhealth.NewClient("apiKey", cloudhealth.WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	q := cloudhealth.CostQuery{From: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2022, 4, 30, 0, 0, 0, 0, time.UTC)}
+	costs, err := c.CostByPerspectiveGroup("1649267441721", q)
+	if err != nil {
+		t.Errorf("CostByPerspectiveGroup() returned an error: %s", err)
+		return
+	}
+	if len(costs) != 3 || costs[0].Name != "Production" || math.Abs(costs[0].Cost-18342.17) > 0.01 {
+		t.Errorf("CostByPerspectiveGroup() returned unexpected costs: %+v", costs)
+	}
+}
+
+func TestFixturePanicsWhenMissing(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Fixture() expected to panic for a missing fixture")
+		}
+	}()
+	Fixture("missing.json")
+}
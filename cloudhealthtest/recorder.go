@@ -0,0 +1,254 @@
+package cloudhealthtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+)
+
+// Mode selects whether a Recorder records live interactions or replays them from a fixture.
+type Mode int
+
+const (
+	// ModeReplay serves responses from the fixture file without contacting CloudHealth.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to CloudHealth and records the interactions for Save.
+	ModeRecord
+)
+
+// sensitiveQueryParams are removed from recorded URLs.
+var sensitiveQueryParams = []string{"api_key"}
+
+// sensitiveHeaders are never written to fixtures.
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// sensitiveFields are the JSON body fields, at any depth, whose values are replaced by redacted in fixtures.
+var sensitiveFields = []string{"access_key", "secret_key", "assume_role_external_id"}
+
+// redacted replaces the values of sensitiveFields.
+const redacted = "REDACTED"
+
+// Interaction is a recorded request and the response CloudHealth returned for it.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the sanitized form of a request sent to CloudHealth.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response returned by CloudHealth.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder records API interactions to a sanitized fixture file and replays them in tests.
+// Credentials are stripped from recorded URLs and headers and redacted from JSON bodies,
+// and requests are matched on method and sanitized URL.
+type Recorder struct {
+	path string
+	mode Mode
+
+	mu           sync.Mutex
+	interactions []Interaction
+	replayed     []bool
+}
+
+// NewRecorder returns a Recorder for the fixture at path. In ModeReplay the fixture is loaded immediately.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{
+		path: path,
+		mode: mode,
+	}
+	if mode == ModeReplay {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &r.interactions); err != nil {
+			return nil, fmt.Errorf("Unable to parse fixture %s: %s", path, err)
+		}
+		r.replayed = make([]bool, len(r.interactions))
+	}
+	return r, nil
+}
+
+// Middleware returns a cloudhealth.Middleware which records the Client's requests or replays them from the fixture.
+func (r *Recorder) Middleware() cloudhealth.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return cloudhealth.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if r.mode == ModeReplay {
+				return r.replay(req)
+			}
+			return r.record(next, req)
+		})
+	}
+}
+
+// Save writes the recorded interactions to the fixture file.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, err := json.MarshalIndent(r.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, data, 0644)
+}
+
+func (r *Recorder) record(next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	recorded, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	header := resp.Header.Clone()
+	for _, h := range sensitiveHeaders {
+		header.Del(h)
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       sanitizeBody(body),
+		},
+	})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	recorded, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, interaction := range r.interactions {
+		if r.replayed[i] || interaction.Request.Method != recorded.Method || interaction.Request.URL != recorded.URL {
+			continue
+		}
+		r.replayed[i] = true
+		return &http.Response{
+			Status:        http.StatusText(interaction.Response.StatusCode),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          ioutil.NopCloser(bytes.NewReader([]byte(interaction.Response.Body))),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("No recorded interaction for %s %s in %s", recorded.Method, recorded.URL, r.path)
+}
+
+// recordRequest returns the sanitized form of req, leaving its body readable.
+func recordRequest(req *http.Request) (RecordedRequest, error) {
+	recorded := RecordedRequest{
+		Method: req.Method,
+		URL:    sanitizeURL(req.URL),
+	}
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return recorded, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		recorded.Body = sanitizeBody(body)
+	}
+	return recorded, nil
+}
+
+// sanitizeURL returns the path and query of u with credentials removed.
+func sanitizeURL(u *url.URL) string {
+	q := u.Query()
+	for _, param := range sensitiveQueryParams {
+		q.Del(param)
+	}
+	sanitized := url.URL{Path: u.Path, RawQuery: q.Encode()}
+	return sanitized.String()
+}
+
+// sanitizeBody returns body with the values of sensitiveFields redacted when it is JSON, and unchanged otherwise.
+func sanitizeBody(body []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+	if !redact(v) {
+		return string(body)
+	}
+	sanitized, err := json.Marshal(v)
+	if err != nil {
+		return string(body)
+	}
+	return string(sanitized)
+}
+
+// redact replaces the values of sensitiveFields in the decoded JSON value v, reporting whether any was found.
+func redact(v interface{}) bool {
+	found := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if isSensitiveField(key) {
+				v[key] = redacted
+				found = true
+			} else if redact(value) {
+				found = true
+			}
+		}
+	case []interface{}:
+		for _, value := range v {
+			if redact(value) {
+				found = true
+			}
+		}
+	}
+	return found
+}
+
+func isSensitiveField(key string) bool {
+	for _, field := range sensitiveFields {
+		if key == field {
+			return true
+		}
+	}
+	return false
+}
+
+// FixtureExists reports whether the fixture at path exists, which is convenient for choosing a Mode.
+func FixtureExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,130 @@
+package cloudhealthtest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+)
+
+var testAwsAccount = cloudhealth.AwsAccount{
+	ID:   1234567890,
+	Name: "test",
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cloudhealthtest")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	fixture := filepath.Join(dir, "get_aws_account.json")
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		body, _ := json.Marshal(testAwsAccount)
+		w.Write(body)
+	}))
+
+	recorder, err := NewRecorder(fixture, ModeRecord)
+	if err != nil {
+		t.Errorf("NewRecorder() returned an error: %s", err)
+		return
+	}
+	c, err := cloudhealth.NewClient("secretApiKey", cloudhealth.WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.Middleware = []cloudhealth.Middleware{recorder.Middleware()}
+	if _, err := c.GetAwsAccount(testAwsAccount.ID); err != nil {
+		t.Errorf("GetAwsAccount() returned an error: %s", err)
+		return
+	}
+	if err := recorder.Save(); err != nil {
+		t.Errorf("Save() returned an error: %s", err)
+		return
+	}
+	ts.Close()
+
+	data, _ := ioutil.ReadFile(fixture)
+	if strings.Contains(string(data), "secretApiKey") {
+		t.Errorf("Expected the fixture to be sanitized, got:\n%s", data)
+	}
+
+	recorder, err = NewRecorder(fixture, ModeReplay)
+	if err != nil {
+		t.Errorf("NewRecorder() returned an error: %s", err)
+		return
+	}
+	c, err = cloudhealth.NewClient("otherApiKey", cloudhealth.WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.Middleware = []cloudhealth.Middleware{recorder.Middleware()}
+	account, err := c.GetAwsAccount(testAwsAccount.ID)
+	if err != nil {
+		t.Errorf("GetAwsAccount() returned an error: %s", err)
+		return
+	}
+	if account.Name != testAwsAccount.Name {
+		t.Errorf("GetAwsAccount() expected Name `%s`, got `%s`", testAwsAccount.Name, account.Name)
+	}
+
+	if _, err := c.GetAwsAccount(testAwsAccount.ID); err == nil {
+		t.Errorf("Expected an error once the recorded interaction was replayed")
+	}
+}
+
+func TestRecordRedactsCredentials(t *testing.T) {
+	dir := t.TempDir()
+	fixture := filepath.Join(dir, "create_aws_account.json")
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":1234567890,"name":"test","authentication":{"protocol":"access_key","access_key":"AKIAXXX"}}`))
+	}))
+	defer ts.Close()
+
+	recorder, err := NewRecorder(fixture, ModeRecord)
+	if err != nil {
+		t.Errorf("NewRecorder() returned an error: %s", err)
+		return
+	}
+	c, err := cloudhealth.NewClient("apiKey", cloudhealth.WithEndpoint(ts.URL), cloudhealth.WithMiddleware(recorder.Middleware()))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	accessKey := testAwsAccount
+	accessKey.Authentication = cloudhealth.NewAccessKeyAuth("AKIAXXX", "SUPERSECRET")
+	assumeRole := testAwsAccount
+	assumeRole.Authentication = cloudhealth.NewAssumeRoleAuth("arn:aws:iam::123456789012:role/CloudHealth", "EXTERNALID")
+	for _, account := range []cloudhealth.AwsAccount{accessKey, assumeRole} {
+		if _, err := c.CreateAwsAccount(account); err != nil {
+			t.Errorf("CreateAwsAccount() returned an error: %s", err)
+			return
+		}
+	}
+	if err := recorder.Save(); err != nil {
+		t.Errorf("Save() returned an error: %s", err)
+		return
+	}
+
+	data, _ := ioutil.ReadFile(fixture)
+	for _, credential := range []string{"AKIAXXX", "SUPERSECRET", "EXTERNALID"} {
+		if strings.Contains(string(data), credential) {
+			t.Errorf("Expected the fixture not to contain %s, got:\n%s", credential, data)
+		}
+	}
+	if !strings.Contains(string(data), "role/CloudHealth") {
+		t.Errorf("Expected the fixture to keep the role ARN, got:\n%s", data)
+	}
+}
@@ -0,0 +1,55 @@
+package cloudhealthtf
+
+import "github.com/nextgenhealthcare/cloudhealth-sdk-go"
+
+// AwsAccountsEquivalent reports whether two AWS Accounts have the same configuration, ignoring the fields
+// CloudHealth reports but doesn't accept (ID, account type and status) and the secret key, which it never returns.
+func AwsAccountsEquivalent(a, b cloudhealth.AwsAccount) bool {
+	aJSON, err := canonicalJSON(configuredAwsAccount(a))
+	if err != nil {
+		return false
+	}
+	bJSON, err := canonicalJSON(configuredAwsAccount(b))
+	if err != nil {
+		return false
+	}
+	return aJSON == bJSON
+}
+
+// configuredAwsAccount returns the configurable part of the AWS Account.
+func configuredAwsAccount(a cloudhealth.AwsAccount) cloudhealth.AwsAccount {
+	a.ID = 0
+	a.AccountType = ""
+	a.Status = nil
+	a.Authentication.SecretKey = ""
+	a.Authentication.SecreyKey = ""
+	return a
+}
+
+// Attributes of an AWS Account that can be updated separately by MergeAwsAccount.
+const (
+	AttrName           = "name"
+	AttrAuthentication = "authentication"
+	AttrBilling        = "billing"
+	AttrCloudTrail     = "cloudtrail"
+)
+
+// MergeAwsAccount returns the live AWS Account with the attributes for which changed returns true, such as
+// Terraform's ResourceData.HasChange, taken from desired. CloudHealth replaces the whole account on update,
+// so this keeps changes made outside of Terraform to the other attributes.
+func MergeAwsAccount(live, desired cloudhealth.AwsAccount, changed func(attr string) bool) cloudhealth.AwsAccount {
+	merged := *live.DeepCopy()
+	if changed(AttrName) {
+		merged.Name = desired.Name
+	}
+	if changed(AttrAuthentication) {
+		merged.Authentication = desired.Authentication
+	}
+	if changed(AttrBilling) {
+		merged.Billing = desired.DeepCopy().Billing
+	}
+	if changed(AttrCloudTrail) {
+		merged.CloudTrail = desired.DeepCopy().CloudTrail
+	}
+	return merged
+}
@@ -0,0 +1,77 @@
+package cloudhealthtf
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+)
+
+func TestAwsAccountsEquivalent(t *testing.T) {
+	desired := cloudhealth.AwsAccount{
+		Name:           "Production",
+		Authentication: cloudhealth.NewAccessKeyAuth("AKIAEXAMPLE", "secret"),
+	}
+	live := desired
+	live.ID = 1234
+	live.AccountType = "Standalone"
+	live.Status = &cloudhealth.AwsAccountStatus{Level: "green"}
+	live.Authentication.SecretKey = ""
+
+	if !AwsAccountsEquivalent(desired, live) {
+		t.Errorf("AwsAccountsEquivalent() expected the live account to be equivalent")
+	}
+	live.Name = "Renamed"
+	if AwsAccountsEquivalent(desired, live) {
+		t.Errorf("AwsAccountsEquivalent() expected a renamed account to differ")
+	}
+}
+
+func TestMergeAwsAccount(t *testing.T) {
+	live := cloudhealth.AwsAccount{
+		ID:         1234,
+		Name:       "Production",
+		CloudTrail: &cloudhealth.AwsAccountCloudTrail{Enabled: true, Bucket: "trail"},
+	}
+	desired := cloudhealth.AwsAccount{
+		Name:    "Renamed",
+		Billing: &cloudhealth.AwsAccountBilling{Bucket: "billing"},
+	}
+
+	merged := MergeAwsAccount(live, desired, func(attr string) bool {
+		return attr == AttrBilling
+	})
+	if merged.ID != 1234 || merged.Name != "Production" || merged.CloudTrail == nil || merged.Billing == nil || merged.Billing.Bucket != "billing" {
+		t.Errorf("MergeAwsAccount() expected only billing to change, got %+v", merged)
+	}
+	merged.Billing.Bucket = "changed"
+	if desired.Billing.Bucket != "billing" {
+		t.Errorf("MergeAwsAccount() returned an account sharing memory with desired")
+	}
+}
+
+func TestMergeAwsAccountUpdate(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":1234,"name":"Production","authentication":{"protocol":"access_key","access_key":"AKIAEXAMPLE"}}`))
+	}))
+	defer ts.Close()
+
+	c, err := cloudhealth.NewClient("apiKey", cloudhealth.WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	live, err := c.GetAwsAccount(1234)
+	if err != nil {
+		t.Errorf("GetAwsAccount() returned an error: %s", err)
+		return
+	}
+	desired := cloudhealth.AwsAccount{Name: "Renamed"}
+	merged := MergeAwsAccount(*live, desired, func(attr string) bool {
+		return attr == AttrName
+	})
+	if _, err := c.UpdateAwsAccount(merged); err != nil {
+		t.Errorf("UpdateAwsAccount() returned an error for a merged account: %s", err)
+	}
+}
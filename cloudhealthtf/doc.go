@@ -0,0 +1,4 @@
+// Package cloudhealthtf provides helpers for Terraform providers managing CloudHealth resources with this SDK:
+// stable serialization, comparisons ignoring the changes CloudHealth makes to stored resources,
+// import ID parsing and partial updates.
+package cloudhealthtf
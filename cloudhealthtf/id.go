@@ -0,0 +1,25 @@
+package cloudhealthtf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseAwsAccountID parses the import ID of an AWS Account, its numeric CloudHealth ID.
+func ParseAwsAccountID(importID string) (int, error) {
+	id, err := strconv.Atoi(strings.TrimSpace(importID))
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("Invalid AWS Account ID `%s`, expected the numeric CloudHealth ID", importID)
+	}
+	return id, nil
+}
+
+// ParsePerspectiveID parses the import ID of a Perspective, its numeric CloudHealth ID.
+func ParsePerspectiveID(importID string) (string, error) {
+	id := strings.TrimSpace(importID)
+	if _, err := strconv.ParseUint(id, 10, 64); err != nil {
+		return "", fmt.Errorf("Invalid Perspective ID `%s`, expected the numeric CloudHealth ID", importID)
+	}
+	return id, nil
+}
@@ -0,0 +1,23 @@
+package cloudhealthtf
+
+import "testing"
+
+func TestParseAwsAccountID(t *testing.T) {
+	if id, err := ParseAwsAccountID(" 5772436045001 "); err != nil || id != 5772436045001 {
+		t.Errorf("ParseAwsAccountID() returned %d, %v", id, err)
+	}
+	for _, invalid := range []string{"", "abc", "-1", "123456789012:role"} {
+		if _, err := ParseAwsAccountID(invalid); err == nil {
+			t.Errorf("ParseAwsAccountID(%q) expected an error", invalid)
+		}
+	}
+}
+
+func TestParsePerspectiveID(t *testing.T) {
+	if id, err := ParsePerspectiveID("1649267441721\n"); err != nil || id != "1649267441721" {
+		t.Errorf("ParsePerspectiveID() returned %q, %v", id, err)
+	}
+	if _, err := ParsePerspectiveID("Environment"); err == nil {
+		t.Errorf("ParsePerspectiveID() expected an error for a name")
+	}
+}
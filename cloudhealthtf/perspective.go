@@ -0,0 +1,71 @@
+package cloudhealthtf
+
+import (
+	"encoding/json"
+
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+)
+
+// PerspectiveJSON serializes the Perspective with sorted keys after normalizing it like PerspectivesEquivalent,
+// so the same schema always produces the same state.
+func PerspectiveJSON(p *cloudhealth.Perspective) (string, error) {
+	if p == nil {
+		return "null", nil
+	}
+	return canonicalJSON(normalizePerspective(p))
+}
+
+// PerspectivesEquivalent reports whether two Perspectives only differ in ways CloudHealth introduces when storing
+// a schema: the "Other" items it adds to static groups, empty constants and empty lists reported as null.
+func PerspectivesEquivalent(a, b *cloudhealth.Perspective) bool {
+	aJSON, err := PerspectiveJSON(a)
+	if err != nil {
+		return false
+	}
+	bJSON, err := PerspectiveJSON(b)
+	if err != nil {
+		return false
+	}
+	return aJSON == bJSON
+}
+
+// normalizePerspective returns a copy of p without the parts CloudHealth adds to stored schemas.
+func normalizePerspective(p *cloudhealth.Perspective) *cloudhealth.Perspective {
+	out := p.DeepCopy()
+	schema := &out.Schema
+	constants := []cloudhealth.Constant{}
+	for _, constant := range schema.Constants {
+		list := []cloudhealth.ConstantItem{}
+		for _, item := range constant.List {
+			if item.IsOther != "true" {
+				list = append(list, item)
+			}
+		}
+		if len(list) > 0 {
+			constant.List = list
+			constants = append(constants, constant)
+		}
+	}
+	schema.Constants = constants
+	if schema.Rules == nil {
+		schema.Rules = []cloudhealth.Rule{}
+	}
+	if schema.Merges == nil {
+		schema.Merges = []interface{}{}
+	}
+	return out
+}
+
+// canonicalJSON encodes v with the keys of every object sorted.
+func canonicalJSON(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return "", err
+	}
+	data, err = json.Marshal(generic)
+	return string(data), err
+}
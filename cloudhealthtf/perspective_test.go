@@ -0,0 +1,49 @@
+package cloudhealthtf
+
+import (
+	"testing"
+
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+)
+
+func TestPerspectivesEquivalent(t *testing.T) {
+	desired := &cloudhealth.Perspective{Schema: cloudhealth.Schema{
+		Name:             "Environment",
+		IncludeInReports: "true",
+		Constants: []cloudhealth.Constant{{
+			Type: cloudhealth.StaticGroupType,
+			List: []cloudhealth.ConstantItem{{RefID: "1", Name: "prod"}},
+		}},
+	}}
+	stored := desired.DeepCopy()
+	stored.Schema.Rules = []cloudhealth.Rule{}
+	stored.Schema.Merges = []interface{}{}
+	stored.Schema.Constants[0].List = append(stored.Schema.Constants[0].List, cloudhealth.ConstantItem{RefID: "2", Name: "Other", IsOther: "true"})
+	stored.Schema.Constants = append(stored.Schema.Constants, cloudhealth.Constant{Type: cloudhealth.DynamicGroupType})
+
+	if !PerspectivesEquivalent(desired, stored) {
+		t.Errorf("PerspectivesEquivalent() expected the stored schema to be equivalent")
+	}
+
+	changed := stored.DeepCopy()
+	changed.Schema.Constants[0].List[0].Name = "production"
+	if PerspectivesEquivalent(desired, changed) {
+		t.Errorf("PerspectivesEquivalent() expected a renamed group to differ")
+	}
+}
+
+func TestPerspectiveJSONStable(t *testing.T) {
+	p := &cloudhealth.Perspective{Schema: cloudhealth.Schema{
+		Name:   "Environment",
+		Merges: []interface{}{map[string]interface{}{"to": "1", "from": []string{"2"}}},
+	}}
+	first, err := PerspectiveJSON(p)
+	if err != nil {
+		t.Errorf("PerspectiveJSON() returned an error: %s", err)
+		return
+	}
+	expected := `{"schema":{"constants":[],"include_in_reports":"","merges":[{"from":["2"],"to":"1"}],"name":"Environment","rules":[]}}`
+	if first != expected {
+		t.Errorf("PerspectiveJSON() expected\n%s\ngot\n%s", expected, first)
+	}
+}
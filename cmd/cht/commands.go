@@ -0,0 +1,280 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+)
+
+// perPage is the page size used to list AWS Accounts.
+const perPage = 100
+
+// command runs the subcommands against a Client.
+type command struct {
+	client *cloudhealth.Client
+	stdin  io.Reader
+	out    printer
+}
+
+func (c *command) run(args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch args[0] {
+	case "ping":
+		status, err := c.client.Ping()
+		if err != nil {
+			return err
+		}
+		return c.out.value(map[string]string{"status": status.String()})
+	case "external-id":
+		id, err := c.client.GetAwsExternalID()
+		if err != nil {
+			return err
+		}
+		return c.out.value(map[string]string{"external_id": id})
+	case "accounts":
+		return c.accounts(args[1:])
+	case "perspectives":
+		return c.perspectives(args[1:])
+	case "costs":
+		return c.costs(args[1:])
+	}
+	return errUsage
+}
+
+func (c *command) accounts(args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch {
+	case args[0] == "list" && len(args) == 1:
+		accounts, err := c.client.GetAllAwsAccounts(perPage)
+		if err != nil {
+			return err
+		}
+		rows := make([][]string, 0, len(accounts))
+		for _, account := range accounts {
+			level := ""
+			if account.Status != nil {
+				level = account.Status.Level
+			}
+			rows = append(rows, []string{strconv.Itoa(account.ID), account.Name, account.AccountType, level})
+		}
+		return c.out.table(accounts, []string{"id", "name", "account_type", "status"}, rows)
+	case args[0] == "get" && len(args) == 2:
+		id, err := strconv.Atoi(args[1])
+		if err != nil {
+			return err
+		}
+		account, err := c.client.GetAwsAccount(id)
+		if err != nil {
+			return err
+		}
+		return c.out.value(account)
+	case args[0] == "create" && len(args) == 2:
+		var account cloudhealth.AwsAccount
+		if err := c.read(args[1], &account); err != nil {
+			return err
+		}
+		created, err := c.client.CreateAwsAccount(account)
+		if err != nil {
+			return err
+		}
+		return c.out.value(created)
+	case args[0] == "update" && len(args) == 2:
+		var account cloudhealth.AwsAccount
+		if err := c.read(args[1], &account); err != nil {
+			return err
+		}
+		updated, err := c.client.UpdateAwsAccount(account)
+		if err != nil {
+			return err
+		}
+		return c.out.value(updated)
+	case args[0] == "delete" && len(args) == 2:
+		id, err := strconv.Atoi(args[1])
+		if err != nil {
+			return err
+		}
+		return c.client.DeleteAwsAccount(id)
+	}
+	return errUsage
+}
+
+func (c *command) perspectives(args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch {
+	case args[0] == "list" && len(args) == 1:
+		perspectives, err := c.client.GetAllPerspectives()
+		if err != nil {
+			return err
+		}
+		ids := make([]string, 0, len(*perspectives))
+		for id := range *perspectives {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		rows := make([][]string, 0, len(ids))
+		for _, id := range ids {
+			p := (*perspectives)[id]
+			rows = append(rows, []string{id, p.Name, strconv.FormatBool(p.Active)})
+		}
+		return c.out.table(perspectives, []string{"id", "name", "active"}, rows)
+	case args[0] == "export" && len(args) == 2:
+		perspective, err := c.client.GetPerspective(args[1])
+		if err != nil {
+			return err
+		}
+		return c.out.value(perspective)
+	case args[0] == "apply":
+		flags := flag.NewFlagSet("apply", flag.ContinueOnError)
+		id := flags.String("id", "", "`ID` of the Perspective to update")
+		if err := flags.Parse(args[1:]); err != nil || flags.NArg() != 1 {
+			return errUsage
+		}
+		var perspective cloudhealth.Perspective
+		if err := c.read(flags.Arg(0), &perspective); err != nil {
+			return err
+		}
+		if *id != "" {
+			updated, err := c.client.UpdatePerspective(*id, &perspective)
+			if err != nil {
+				return err
+			}
+			return c.out.value(updated)
+		}
+		created, err := c.client.CreatePerspective(&perspective)
+		if err != nil {
+			return err
+		}
+		return c.out.value(map[string]string{"id": created})
+	case args[0] == "copy":
+		flags := flag.NewFlagSet("copy", flag.ContinueOnError)
+		targetKey := flags.String("target-api-key", "", "API `key` of the target tenant")
+		if err := flags.Parse(args[1:]); err != nil || *targetKey == "" || flags.NArg() == 0 {
+			return errUsage
+		}
+		target, err := cloudhealth.NewClient(*targetKey, cloudhealth.WithEndpoint(c.client.EndpointURL.String()))
+		if err != nil {
+			return err
+		}
+		target.BasePath = c.client.BasePath
+		target.AuthMode = c.client.AuthMode
+		results, err := cloudhealth.CopyPerspectives(c.client, target, flags.Args())
+		if err != nil {
+			return err
+		}
+		rows := make([][]string, 0, len(results))
+		failed := 0
+		for _, r := range results {
+			errText := ""
+			if r.Err != nil {
+				errText = r.Err.Error()
+				failed++
+			}
+			rows = append(rows, []string{r.SourceID, r.Name, r.TargetID, strconv.FormatBool(r.Created), errText})
+		}
+		if err := c.out.table(copyResults(results), []string{"source_id", "name", "target_id", "created", "error"}, rows); err != nil {
+			return err
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d Perspectives failed to copy", failed, len(results))
+		}
+		return nil
+	}
+	return errUsage
+}
+
+func (c *command) costs(args []string) error {
+	if len(args) == 0 || (args[0] != "perspective" && args[0] != "tag") {
+		return errUsage
+	}
+	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	from := flags.String("from", "", "first `month` or day of the report, as 2006-01 or 2006-01-02")
+	to := flags.String("to", "", "last `month` or day of the report, defaulting to -from")
+	daily := flags.Bool("daily", false, "report daily instead of monthly costs")
+	basis := flags.String("basis", "", "cost `measure`, such as amortized_cost, defaulting to unblended cost")
+	orgID := flags.String("org-id", "", "`ID` of the FlexOrg organization to report on")
+	if err := flags.Parse(args[1:]); err != nil || flags.NArg() != 1 || *from == "" {
+		return errUsage
+	}
+	if *to == "" {
+		to = from
+	}
+	q := cloudhealth.CostQuery{Basis: cloudhealth.CostBasis(*basis), OrgID: *orgID}
+	var err error
+	if q.From, err = parseDate(*from); err != nil {
+		return err
+	}
+	if q.To, err = parseDate(*to); err != nil {
+		return err
+	}
+	if *daily {
+		q.Interval = cloudhealth.IntervalDaily
+	}
+
+	var costs []cloudhealth.GroupCost
+	if args[0] == "perspective" {
+		costs, err = c.client.CostByPerspectiveGroup(flags.Arg(0), q)
+	} else {
+		costs, err = c.client.CostByTag(flags.Arg(0), q)
+	}
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, 0, len(costs))
+	for _, cost := range costs {
+		rows = append(rows, []string{cost.ID, cost.Name, strconv.FormatFloat(cost.Cost, 'f', 2, 64)})
+	}
+	return c.out.table(costs, []string{"id", "name", "cost"}, rows)
+}
+
+// parseDate parses a day, or a month standing for its first day.
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01", value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// copyResults encodes PerspectiveCopy results with their errors as text.
+func copyResults(results []cloudhealth.PerspectiveCopy) []map[string]interface{} {
+	out := make([]map[string]interface{}, 0, len(results))
+	for _, r := range results {
+		result := map[string]interface{}{
+			"source_id": r.SourceID,
+			"name":      r.Name,
+			"target_id": r.TargetID,
+			"created":   r.Created,
+		}
+		if r.Err != nil {
+			result["error"] = r.Err.Error()
+		}
+		out = append(out, result)
+	}
+	return out
+}
+
+// read decodes the JSON file at path, or stdin when path is "-", into v.
+func (c *command) read(path string, v interface{}) error {
+	r := c.stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	return json.NewDecoder(r).Decode(v)
+}
@@ -0,0 +1,80 @@
+// Command cht scripts the CloudHealth API from the command line.
+//
+// Usage:
+//
+//	cht [-config file] [-output json|csv] <command> [arguments]
+//
+// The commands are:
+//
+//	ping                       check the credentials
+//	external-id                print the AWS External ID of the tenant
+//	accounts list              list the AWS Accounts
+//	accounts get <id>          print an AWS Account
+//	accounts create <file>     enable the AWS Account read from file, "-" for stdin
+//	accounts update <file>     update the AWS Account read from file, "-" for stdin
+//	accounts delete <id>       remove an AWS Account
+//	perspectives list          list the Perspectives
+//	perspectives export <id>   print a Perspective
+//	perspectives apply <file>  create the Perspective read from file, or update it when -id is given
+//	perspectives copy <id>...  copy Perspectives to the tenant of -target-api-key, see cloudhealth.CopyPerspectives
+//	costs perspective <id>     print the cost of each group of a Perspective from -from to -to, see below
+//	costs tag <key>            print the cost of each value of an AWS tag key from -from to -to
+//
+// The costs commands take the months or days to report on as -from 2006-01 and -to 2006-03, or -from 2006-01-02
+// with -daily. -basis selects the cost measure, such as amortized_cost, and -org-id a FlexOrg organization.
+//
+// The client is configured by cloudhealth.LoadConfig from the -config file and the CLOUDHEALTH_* environment variables.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// errUsage is returned by commands called with the wrong arguments.
+var errUsage = errors.New("invalid arguments, see the package documentation for usage")
+
+// run executes the command line args, returning the exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("cht", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	configPath := flags.String("config", "", "JSON config `file`")
+	output := flags.String("output", "json", "output `format` of listings, json or csv")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	config, err := cloudhealth.LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	client, err := config.NewClient()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	cmd := &command{client: client, stdin: stdin, out: newPrinter(*output, stdout)}
+	if cmd.out == nil {
+		fmt.Fprintf(stderr, "unknown output format %s\n", *output)
+		return 2
+	}
+	if err := cmd.run(flags.Args()); err != nil {
+		fmt.Fprintln(stderr, err)
+		if errors.Is(err, errUsage) {
+			return 2
+		}
+		return 1
+	}
+	return 0
+}
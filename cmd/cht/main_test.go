@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go/cloudhealthtest"
+)
+
+// runCLI runs the command line against the CloudHealth API served at endpoint.
+func runCLI(t *testing.T, endpoint string, stdin io.Reader, args ...string) (int, string, string) {
+	t.Setenv("CLOUDHEALTH_ENDPOINT_URL", endpoint)
+	t.Setenv("CLOUDHEALTH_API_KEY", "apiKey")
+	var stdout, stderr bytes.Buffer
+	status := run(args, stdin, &stdout, &stderr)
+	return status, stdout.String(), stderr.String()
+}
+
+func TestAccountsListCSV(t *testing.T) {
+	ts := cloudhealthtest.NewServer()
+	defer ts.Close()
+
+	status, stdout, stderr := runCLI(t, ts.URL, nil, "-output", "csv", "accounts", "list")
+	if status != 0 {
+		t.Errorf("run() exited with %d: %s", status, stderr)
+		return
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if lines[0] != "id,name,account_type,status" || !strings.HasPrefix(lines[1], "5772436045001,Production Payer,") {
+		t.Errorf("Expected a CSV listing of the AWS Accounts, got:\n%s", stdout)
+	}
+}
+
+func TestPerspectivesListJSON(t *testing.T) {
+	ts := cloudhealthtest.NewServer()
+	defer ts.Close()
+
+	status, stdout, stderr := runCLI(t, ts.URL, nil, "perspectives", "list")
+	if status != 0 {
+		t.Errorf("run() exited with %d: %s", status, stderr)
+		return
+	}
+	var perspectives map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout), &perspectives); err != nil || len(perspectives) != 2 {
+		t.Errorf("Expected a JSON listing of the Perspectives, got:\n%s", stdout)
+	}
+}
+
+func TestPerspectivesApply(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/perspective_schemas/42" {
+			t.Errorf("Expected ‘PUT’ request to ‘/perspective_schemas/42’, got ‘%s’ request to ‘%s’", r.Method, r.URL.Path)
+		}
+		io.Copy(w, r.Body)
+	}))
+	defer ts.Close()
+
+	perspective := `{"schema":{"name":"Environment","include_in_reports":"true"}}`
+	status, stdout, stderr := runCLI(t, ts.URL, strings.NewReader(perspective), "perspectives", "apply", "-id", "42", "-")
+	if status != 0 {
+		t.Errorf("run() exited with %d: %s", status, stderr)
+		return
+	}
+	if !strings.Contains(stdout, `"name": "Environment"`) {
+		t.Errorf("Expected the updated Perspective, got:\n%s", stdout)
+	}
+}
+
+func TestCostsPerspectiveCSV(t *testing.T) {
+	ts := cloudhealthtest.NewServer()
+	defer ts.Close()
+
+	status, stdout, stderr := runCLI(t, ts.URL, nil, "-output", "csv", "costs", "perspective", "-from", "2022-03", "-to", "2022-04", "1649267441721")
+	if status != 0 {
+		t.Errorf("run() exited with %d: %s", status, stderr)
+		return
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 4 || lines[0] != "id,name,cost" || lines[1] != "1649267441731,Production,18342.17" {
+		t.Errorf("Expected a CSV listing of the group costs, got:\n%s", stdout)
+	}
+}
+
+func TestCostsTagJSON(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("filters[]") != "time:select:2024-01-30,2024-01-31" || query.Get("measures[]") != "amortized_cost" || query.Get("org_id") != "7" {
+			t.Errorf("Unexpected cost report query: %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"dimensions":[{"time":[{"name":"2024-01-30"},{"name":"2024-01-31"}]},{"AWS-Tag-team":[{"name":"search","label":"search"}]}],"data":[[[1.5]],[[2]]]}`))
+	}))
+	defer ts.Close()
+
+	status, stdout, stderr := runCLI(t, ts.URL, nil, "costs", "tag", "-from", "2024-01-30", "-to", "2024-01-31", "-daily", "-basis", "amortized_cost", "-org-id", "7", "team")
+	if status != 0 {
+		t.Errorf("run() exited with %d: %s", status, stderr)
+		return
+	}
+	var costs []map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout), &costs); err != nil || len(costs) != 1 || costs[0]["cost"] != 3.5 {
+		t.Errorf("Expected a JSON listing of the tag value costs, got:\n%s", stdout)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	for _, args := range [][]string{{}, {"accounts"}, {"accounts", "get"}, {"reports"}, {"costs", "tag", "team"}} {
+		if status, _, _ := runCLI(t, "http://localhost", nil, args...); status != 2 {
+			t.Errorf("run(%q) expected exit status 2, got %d", args, status)
+		}
+	}
+}
+
+func TestPerspectivesCopy(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		switch {
+		case key == "apiKey" && r.Method == "GET":
+			w.Write([]byte(`{"schema":{"name":"Environment","include_in_reports":"true"}}`))
+		case key == "targetKey" && r.Method == "GET":
+			w.Write([]byte(`{}`))
+		case key == "targetKey" && r.Method == "POST":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte("Perspective 21 created"))
+		default:
+			t.Errorf("Unexpected ‘%s’ request to ‘%s’ with API key ‘%s’", r.Method, r.URL.Path, key)
+		}
+	}))
+	defer ts.Close()
+
+	status, stdout, stderr := runCLI(t, ts.URL, nil, "-output", "csv", "perspectives", "copy", "-target-api-key", "targetKey", "10")
+	if status != 0 {
+		t.Errorf("run() exited with %d: %s", status, stderr)
+		return
+	}
+	if !strings.Contains(stdout, "10,Environment,21,true,") {
+		t.Errorf("Expected the copy results, got:\n%s", stdout)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+)
+
+// printer writes command results in the selected output format.
+type printer interface {
+	// value writes a single result.
+	value(v interface{}) error
+	// table writes a listing, either as v or as the rows under header.
+	table(v interface{}, header []string, rows [][]string) error
+}
+
+// newPrinter returns the printer for format, or nil when the format is unknown.
+func newPrinter(format string, w io.Writer) printer {
+	switch format {
+	case "json":
+		return jsonPrinter{w}
+	case "csv":
+		return csvPrinter{w}
+	}
+	return nil
+}
+
+type jsonPrinter struct {
+	w io.Writer
+}
+
+func (p jsonPrinter) value(v interface{}) error {
+	enc := json.NewEncoder(p.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func (p jsonPrinter) table(v interface{}, header []string, rows [][]string) error {
+	return p.value(v)
+}
+
+// csvPrinter writes listings as CSV. Single results are still written as JSON.
+type csvPrinter struct {
+	w io.Writer
+}
+
+func (p csvPrinter) value(v interface{}) error {
+	return jsonPrinter(p).value(v)
+}
+
+func (p csvPrinter) table(v interface{}, header []string, rows [][]string) error {
+	w := csv.NewWriter(p.w)
+	w.Write(header)
+	w.WriteAll(rows)
+	return w.Error()
+}
@@ -0,0 +1,87 @@
+package cloudhealth
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// coalescingTransport makes a single upstream call for identical GET requests that are in flight
+// at the same time, handing every caller its own copy of the shared response. The call is sent with
+// the context of its first caller; when that caller gives up, the others send the request again
+// rather than fail with an error that isn't theirs.
+type coalescingTransport struct {
+	next http.RoundTripper
+
+	mu    sync.Mutex
+	calls map[string]*coalescedCall
+}
+
+// coalescedCall is a GET request in flight, shared by every caller waiting on it.
+type coalescedCall struct {
+	done chan struct{} // closed once the response is read
+	resp *http.Response
+	body []byte
+	err  error
+	// abandoned is set when the call failed because the context of its first caller is done.
+	abandoned bool
+}
+
+func newCoalescingTransport(next http.RoundTripper) *coalescingTransport {
+	return &coalescingTransport{
+		next:  next,
+		calls: make(map[string]*coalescedCall),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *coalescingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != "GET" {
+		return t.next.RoundTrip(req)
+	}
+	key := requestKey(req)
+
+	t.mu.Lock()
+	if call, ok := t.calls[key]; ok {
+		t.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		if call.abandoned {
+			return t.RoundTrip(req)
+		}
+		return call.response(req)
+	}
+	call := &coalescedCall{done: make(chan struct{})}
+	t.calls[key] = call
+	t.mu.Unlock()
+
+	call.resp, call.err = t.next.RoundTrip(req)
+	if call.err == nil {
+		call.body, call.err = ioutil.ReadAll(call.resp.Body)
+		call.resp.Body.Close()
+	}
+	call.abandoned = call.err != nil && req.Context().Err() != nil
+
+	t.mu.Lock()
+	delete(t.calls, key)
+	t.mu.Unlock()
+	close(call.done)
+
+	return call.response(req)
+}
+
+// response returns a copy of the shared response with its own body for req.
+func (c *coalescedCall) response(req *http.Request) (*http.Response, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	resp := *c.resp
+	resp.Header = c.resp.Header.Clone()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(c.body))
+	resp.Request = req
+	return &resp, nil
+}
@@ -0,0 +1,147 @@
+package cloudhealth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCoalesceGets(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		w.WriteHeader(http.StatusOK)
+		body, _ := json.Marshal(defaultAWSAccount)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.CoalesceGets = true
+	var started int32
+	c.RequestIDFunc = func() string {
+		atomic.AddInt32(&started, 1)
+		return "id"
+	}
+
+	const callers = 5
+	var wg sync.WaitGroup
+	accounts := make([]*AwsAccount, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			accounts[i], errs[i] = c.GetAwsAccount(defaultAWSAccount.ID)
+		}(i)
+	}
+
+	// Give every other caller time to join the first upstream call
+	for atomic.LoadInt32(&started) != callers || atomic.LoadInt32(&requests) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i := 0; i < callers; i++ {
+		if errs[i] != nil {
+			t.Errorf("GetAwsAccount() returned an error: %s", errs[i])
+			return
+		}
+		if accounts[i].ID != defaultAWSAccount.ID {
+			t.Errorf("GetAwsAccount() expected ID `%d`, got `%d`", defaultAWSAccount.ID, accounts[i].ID)
+			return
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("Expected 1 upstream request, got %d", n)
+	}
+}
+
+func TestCoalesceGetsIgnoresWrites(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.CoalesceGets = true
+
+	for i := 0; i < 2; i++ {
+		if err := c.DeleteAwsAccount(defaultAWSAccount.ID); err != nil {
+			t.Errorf("DeleteAwsAccount() returned an error: %s", err)
+			return
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("Expected 2 upstream requests, got %d", n)
+	}
+}
+
+func TestCoalesceGetsLeaderCanceled(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			<-r.Context().Done()
+			return
+		}
+		body, _ := json.Marshal(defaultAWSAccount)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.CoalesceGets = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := c.GetAwsAccount(defaultAWSAccount.ID, WithContext(ctx))
+		leaderErr <- err
+	}()
+	for atomic.LoadInt32(&requests) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	followerErr := make(chan error, 1)
+	go func() {
+		_, err := c.GetAwsAccount(defaultAWSAccount.ID)
+		followerErr <- err
+	}()
+	// Give the follower time to join the leader's call
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("GetAwsAccount() expected context.Canceled for the leader, got %v", err)
+	}
+	if err := <-followerErr; err != nil {
+		t.Errorf("GetAwsAccount() returned an error for the follower: %s", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("Expected the follower to send the request again, got %d upstream requests", n)
+	}
+}
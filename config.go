@@ -0,0 +1,135 @@
+package cloudhealth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultEndpointURL is the CloudHealth API endpoint used when a Config doesn't set one.
+const DefaultEndpointURL = "https://chapi.cloudhealthtech.com/v1/"
+
+// Config holds Client settings loaded from a JSON file and the environment by LoadConfig.
+type Config struct {
+	EndpointURL string `json:"endpoint_url"`
+	BasePath    string `json:"base_path"`
+	ApiKey      string `json:"api_key"`
+	// ApiKeyFile references a file holding the API key, re-read before every request so the key can rotate.
+	ApiKeyFile string `json:"api_key_file"`
+	// AuthMode is either "bearer" (the default) or "api_key".
+	AuthMode string `json:"auth_mode"`
+	Timeout  int    `json:"timeout"` // in seconds
+
+	// MaxAttempts of 2 or more retries failed requests with a RetryPolicy waiting RetryBaseDelay before the first
+	// retry, doubled up to RetryMaxDelay. The delays are durations such as "500ms", defaulting to 1s and 30s.
+	MaxAttempts    int    `json:"max_attempts"`
+	RetryBaseDelay string `json:"retry_base_delay"`
+	RetryMaxDelay  string `json:"retry_max_delay"`
+}
+
+// Default delays of the RetryPolicy configured by a Config.
+const (
+	defaultRetryBaseDelay = time.Second
+	defaultRetryMaxDelay  = 30 * time.Second
+)
+
+// configEnv maps the environment variables read by LoadConfig to the Config fields they override.
+var configEnv = []struct {
+	name string
+	set  func(*Config, string) error
+}{
+	{"CLOUDHEALTH_ENDPOINT_URL", func(c *Config, v string) error { c.EndpointURL = v; return nil }},
+	{"CLOUDHEALTH_BASE_PATH", func(c *Config, v string) error { c.BasePath = v; return nil }},
+	{"CLOUDHEALTH_API_KEY", func(c *Config, v string) error { c.ApiKey = v; return nil }},
+	{"CLOUDHEALTH_API_KEY_FILE", func(c *Config, v string) error { c.ApiKeyFile = v; return nil }},
+	{"CLOUDHEALTH_AUTH_MODE", func(c *Config, v string) error { c.AuthMode = v; return nil }},
+	{"CLOUDHEALTH_TIMEOUT", func(c *Config, v string) (err error) { c.Timeout, err = strconv.Atoi(v); return err }},
+	{"CLOUDHEALTH_MAX_ATTEMPTS", func(c *Config, v string) (err error) { c.MaxAttempts, err = strconv.Atoi(v); return err }},
+	{"CLOUDHEALTH_RETRY_BASE_DELAY", func(c *Config, v string) error { c.RetryBaseDelay = v; return nil }},
+	{"CLOUDHEALTH_RETRY_MAX_DELAY", func(c *Config, v string) error { c.RetryMaxDelay = v; return nil }},
+}
+
+// LoadConfig reads the JSON config file at path, when path is not empty, then overrides its settings
+// with the CLOUDHEALTH_* environment variables that are set.
+func LoadConfig(path string) (*Config, error) {
+	config := &Config{}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, config); err != nil {
+			return nil, fmt.Errorf("Invalid config file %s: %w", path, err)
+		}
+	}
+	for _, env := range configEnv {
+		if v, ok := os.LookupEnv(env.name); ok {
+			if err := env.set(config, v); err != nil {
+				return nil, fmt.Errorf("Invalid %s: %w", env.name, err)
+			}
+		}
+	}
+	return config, nil
+}
+
+// NewClient returns a Client configured from the Config.
+func (c *Config) NewClient() (*Client, error) {
+	endpointURL := c.EndpointURL
+	if endpointURL == "" {
+		endpointURL = DefaultEndpointURL
+	}
+	s, err := NewClient(c.ApiKey, WithEndpoint(endpointURL))
+	if err != nil {
+		return nil, err
+	}
+	s.BasePath = c.BasePath
+	if c.Timeout > 0 {
+		s.Timeout = time.Duration(c.Timeout) * time.Second
+	}
+	switch c.AuthMode {
+	case "", "bearer":
+	case "api_key":
+		s.AuthMode = AuthAPIKey
+	default:
+		return nil, fmt.Errorf("Unknown auth mode `%s`", c.AuthMode)
+	}
+	if c.MaxAttempts > 1 {
+		baseDelay, err := parseDelay("retry_base_delay", c.RetryBaseDelay, defaultRetryBaseDelay)
+		if err != nil {
+			return nil, err
+		}
+		maxDelay, err := parseDelay("retry_max_delay", c.RetryMaxDelay, defaultRetryMaxDelay)
+		if err != nil {
+			return nil, err
+		}
+		if err := WithRetry(NewRetryPolicy(c.MaxAttempts, baseDelay, maxDelay))(s); err != nil {
+			return nil, err
+		}
+	}
+	if c.ApiKeyFile != "" {
+		file := c.ApiKeyFile
+		s.ApiKeyProvider = func() (string, error) {
+			key, err := os.ReadFile(file)
+			if err != nil {
+				return "", err
+			}
+			return strings.TrimSpace(string(key)), nil
+		}
+	}
+	return s, nil
+}
+
+// parseDelay parses the duration v of the setting name, returning def when v is empty.
+func parseDelay(name, v string, def time.Duration) (time.Duration, error) {
+	if v == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid %s: %w", name, err)
+	}
+	return d, nil
+}
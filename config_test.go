@@ -0,0 +1,95 @@
+package cloudhealth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cloudhealth.json")
+	keyFile := filepath.Join(dir, "key")
+	os.WriteFile(path, []byte(`{"endpoint_url":"https://gateway.example.com/","base_path":"/cloudhealth/v1","api_key_file":"`+keyFile+`","timeout":30}`), 0600)
+	os.WriteFile(keyFile, []byte("fileKey\n"), 0600)
+	t.Setenv("CLOUDHEALTH_TIMEOUT", "45")
+	t.Setenv("CLOUDHEALTH_AUTH_MODE", "api_key")
+
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Errorf("LoadConfig() returned an error: %s", err)
+		return
+	}
+	if config.Timeout != 45 || config.BasePath != "/cloudhealth/v1" || config.AuthMode != "api_key" {
+		t.Errorf("LoadConfig() returned an unexpected config: %+v", config)
+		return
+	}
+
+	c, err := config.NewClient()
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if c.EndpointURL.String() != "https://gateway.example.com/" || c.BasePath != "/cloudhealth/v1" || c.Timeout != 45*time.Second || c.AuthMode != AuthAPIKey {
+		t.Errorf("NewClient() returned an unexpected Client: %+v", c)
+	}
+	if key, err := c.apiKey(); err != nil || key != "fileKey" {
+		t.Errorf("Expected the API key to be read from the key file, got ‘%s’, %v", key, err)
+	}
+}
+
+func TestLoadConfigEnvOnly(t *testing.T) {
+	t.Setenv("CLOUDHEALTH_API_KEY", "envKey")
+
+	config, err := LoadConfig("")
+	if err != nil {
+		t.Errorf("LoadConfig() returned an error: %s", err)
+		return
+	}
+	c, err := config.NewClient()
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if c.ApiKey != "envKey" || c.EndpointURL.String() != DefaultEndpointURL || c.Timeout != defaultTimeout {
+		t.Errorf("NewClient() returned an unexpected Client: %+v", c)
+	}
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	t.Setenv("CLOUDHEALTH_TIMEOUT", "soon")
+	if _, err := LoadConfig(""); err == nil {
+		t.Errorf("LoadConfig() expected an error for an invalid timeout")
+	}
+
+	config := &Config{AuthMode: "basic"}
+	if _, err := config.NewClient(); err == nil {
+		t.Errorf("NewClient() expected an error for an unknown auth mode")
+	}
+}
+
+func TestLoadConfigRetry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cloudhealth.json")
+	os.WriteFile(path, []byte(`{"max_attempts":3,"retry_base_delay":"500ms"}`), 0600)
+	t.Setenv("CLOUDHEALTH_RETRY_MAX_DELAY", "10s")
+
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Errorf("LoadConfig() returned an error: %s", err)
+		return
+	}
+	c, err := config.NewClient()
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if c.Retry == nil || c.Retry.MaxAttempts != 3 || c.Retry.BaseDelay != 500*time.Millisecond || c.Retry.MaxDelay != 10*time.Second {
+		t.Errorf("NewClient() returned an unexpected RetryPolicy: %+v", c.Retry)
+	}
+
+	config.RetryBaseDelay = "soon"
+	if _, err := config.NewClient(); err == nil {
+		t.Errorf("NewClient() expected an error for an invalid retry delay")
+	}
+}
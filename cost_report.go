@@ -0,0 +1,163 @@
+package cloudhealth
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ReportInterval is the granularity of the time dimension of an OLAP report.
+type ReportInterval string
+
+// Report intervals.
+const (
+	IntervalMonthly ReportInterval = "monthly"
+	IntervalDaily   ReportInterval = "daily"
+)
+
+// CostBasis is the measure of the cost history report a cost helper sums. Other measures enabled for the tenant
+// can be given as a CostBasis too.
+type CostBasis string
+
+// Cost bases.
+const (
+	CostUnblended CostBasis = "cost"
+	CostAmortized CostBasis = "amortized_cost"
+	CostList      CostBasis = "list_cost"
+	CostNet       CostBasis = "net_cost"
+)
+
+// CostQuery selects the time range of a cost report, From and To included. Interval defaults to IntervalMonthly
+// and Basis to CostUnblended. OrgID, when set, scopes the report to a FlexOrg organization.
+type CostQuery struct {
+	From     time.Time
+	To       time.Time
+	Interval ReportInterval
+	Basis    CostBasis
+	OrgID    string
+}
+
+// GroupCost is the cost of a member of a report dimension, such as a Perspective group or tag value, over a CostQuery.
+type GroupCost struct {
+	ID   string  `json:"id"`
+	Name string  `json:"name"`
+	Cost float64 `json:"cost"`
+}
+
+// costReport is the subset of an OLAP report response used by the cost helpers.
+type costReport struct {
+	// Dimensions lists, in the order requested, a single dimension mapped to its members.
+	Dimensions []map[string][]reportMember `json:"dimensions"`
+	// Data is indexed by the member of each dimension, then by measure. Missing values are null.
+	Data [][][]*float64 `json:"data"`
+}
+
+type reportMember struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+}
+
+const costHistoryReport = "olap_reports/cost/history"
+
+// totalMember is the member of report dimensions aggregating the others.
+const totalMember = "total"
+
+// CostByPerspectiveGroup returns the cost of each group of the Perspective with the given ID over the time range
+// of q, from the cost history report. The Perspective is read first, as reports on unknown Perspectives don't
+// fail with a 404: ErrPerspectiveNotFound is returned when it doesn't exist.
+func (s *Client) CostByPerspectiveGroup(perspectiveID string, q CostQuery, opts ...CallOption) (costs []GroupCost, err error) {
+	defer annotate(&err, "CostByPerspectiveGroup", perspectiveID)
+	if _, err := s.getPerspective(perspectiveID, opts); err != nil {
+		return nil, err
+	}
+	return s.costBy(PerspectiveDimension(perspectiveID), q, opts)
+}
+
+// CostByTag returns the cost of each value of the AWS tag key over the time range of q, from the cost history
+// report.
+func (s *Client) CostByTag(key string, q CostQuery, opts ...CallOption) (costs []GroupCost, err error) {
+	defer annotate(&err, "CostByTag", key)
+	return s.costBy(TagDimension(key), q, opts)
+}
+
+// costBy returns the cost of each member of dim over the time range of q, summed across its intervals.
+func (s *Client) costBy(dim ReportDimension, q CostQuery, opts []CallOption) ([]GroupCost, error) {
+	members, err := q.timeMembers()
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{
+		"interval":     {string(q.interval())},
+		"dimensions[]": {string(DimensionTime), string(dim)},
+		"measures[]":   {string(q.basis())},
+		"filters[]":    {DimensionTime.Select(members...)},
+	}
+	if q.OrgID != "" {
+		query.Set("org_id", q.OrgID)
+	}
+	report, err := do[costReport](s, apiCall{method: "GET", path: costHistoryReport, query: query, options: opts})
+	if err != nil {
+		return nil, err
+	}
+	if len(report.Dimensions) != 2 {
+		return nil, errors.New("Unexpected dimensions in the cost report")
+	}
+	times, groups := report.Dimensions[0][string(DimensionTime)], report.Dimensions[1][string(dim)]
+
+	var costs []GroupCost
+	for g, group := range groups {
+		if group.Name == totalMember {
+			continue
+		}
+		cost := GroupCost{ID: group.Name, Name: group.Label}
+		for t, interval := range times {
+			if interval.Name == totalMember || t >= len(report.Data) || g >= len(report.Data[t]) {
+				continue
+			}
+			if values := report.Data[t][g]; len(values) > 0 && values[0] != nil {
+				cost.Cost += *values[0]
+			}
+		}
+		costs = append(costs, cost)
+	}
+	return costs, nil
+}
+
+func (q CostQuery) interval() ReportInterval {
+	if q.Interval == "" {
+		return IntervalMonthly
+	}
+	return q.Interval
+}
+
+func (q CostQuery) basis() CostBasis {
+	if q.Basis == "" {
+		return CostUnblended
+	}
+	return q.Basis
+}
+
+// timeMembers returns the members of DimensionTime covering the time range of q.
+func (q CostQuery) timeMembers() ([]string, error) {
+	if q.From.IsZero() || q.To.Before(q.From) {
+		return nil, errors.New("Invalid cost report time range")
+	}
+	var layout string
+	var next func(time.Time) time.Time
+	switch q.interval() {
+	case IntervalMonthly:
+		layout, next = "2006-01", func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }
+	case IntervalDaily:
+		layout, next = "2006-01-02", func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+	default:
+		return nil, fmt.Errorf("Unknown cost report interval `%s`", q.Interval)
+	}
+
+	var members []string
+	start, _ := time.Parse(layout, q.From.Format(layout))
+	for t := start; t.Format(layout) <= q.To.Format(layout); t = next(t) {
+		members = append(members, t.Format(layout))
+	}
+	return members, nil
+}
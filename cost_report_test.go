@@ -0,0 +1,160 @@
+package cloudhealth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var defaultCostReport = `{
+	"dimensions": [
+		{"time": [{"name": "total", "label": "Total"}, {"name": "2024-01", "label": "Jan 2024"}, {"name": "2024-02", "label": "Feb 2024"}]},
+		{"1234567839263": [{"name": "total", "label": "Total"}, {"name": "1", "label": "Production"}, {"name": "2", "label": "Staging"}]}
+	],
+	"measures": [{"name": "cost", "label": "Cost ($)"}],
+	"interval": "monthly",
+	"data": [
+		[[35.5], [30.5], [5.0]],
+		[[15.25], [10.0], [5.25]],
+		[[20.25], [20.5], [null]]
+	]
+}`
+
+func TestCostByPerspectiveGroupOK(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/perspective_schemas/" + defaultPerspectiveID:
+			json.NewEncoder(w).Encode(defaultPerspective)
+		case "/olap_reports/cost/history":
+			query := r.URL.Query()
+			if dims := query["dimensions[]"]; !reflect.DeepEqual(dims, []string{"time", defaultPerspectiveID}) {
+				t.Errorf("Expected time and perspective dimensions, got %v", dims)
+			}
+			if filter := query.Get("filters[]"); filter != "time:select:2024-01,2024-02" {
+				t.Errorf("Expected a time filter of the range, got ‘%s’", filter)
+			}
+			if measure := query.Get("measures[]"); measure != "cost" {
+				t.Errorf("Expected the unblended cost measure, got ‘%s’", measure)
+			}
+			if _, ok := query["org_id"]; ok {
+				t.Errorf("Expected no org_id without an organization")
+			}
+			if interval := query.Get("interval"); interval != "monthly" {
+				t.Errorf("Expected a monthly interval, got ‘%s’", interval)
+			}
+			w.Write([]byte(defaultCostReport))
+		default:
+			t.Errorf("Unexpected request to ‘%s’", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	q := CostQuery{From: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)}
+	costs, err := c.CostByPerspectiveGroup(defaultPerspectiveID, q)
+	if err != nil {
+		t.Errorf("CostByPerspectiveGroup() returned an error: %s", err)
+		return
+	}
+	expected := []GroupCost{{ID: "1", Name: "Production", Cost: 30.5}, {ID: "2", Name: "Staging", Cost: 5.25}}
+	if !reflect.DeepEqual(costs, expected) {
+		t.Errorf("CostByPerspectiveGroup() returned %v, expected %v", costs, expected)
+	}
+}
+
+func TestCostByPerspectiveGroupDoesntExist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/perspective_schemas/"+defaultPerspectiveID {
+			t.Errorf("Unexpected request to ‘%s’", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(emptyPerspective)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	q := CostQuery{From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)}
+	if _, err := c.CostByPerspectiveGroup(defaultPerspectiveID, q); !errors.Is(err, ErrPerspectiveNotFound) {
+		t.Errorf("CostByPerspectiveGroup() didn't return ErrPerspectiveNotFound: %v", err)
+	}
+}
+
+func TestCostByTagOK(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/olap_reports/cost/history" {
+			t.Errorf("Unexpected request to ‘%s’", r.URL.Path)
+		}
+		if dims := r.URL.Query()["dimensions[]"]; !reflect.DeepEqual(dims, []string{"time", "AWS-Tag-team"}) {
+			t.Errorf("Expected time and tag dimensions, got %v", dims)
+		}
+		if measure := r.URL.Query().Get("measures[]"); measure != "amortized_cost" {
+			t.Errorf("Expected the amortized cost measure, got ‘%s’", measure)
+		}
+		if orgID := r.URL.Query().Get("org_id"); orgID != "42" {
+			t.Errorf("Expected the org_id of the query, got ‘%s’", orgID)
+		}
+		w.Write([]byte(`{
+			"dimensions": [
+				{"time": [{"name": "2024-01", "label": "Jan 2024"}]},
+				{"AWS-Tag-team": [{"name": "payments", "label": "payments"}, {"name": "search", "label": "search"}]}
+			],
+			"data": [[[12.5], [7.5]]]
+		}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	q := CostQuery{From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), Basis: CostAmortized, OrgID: "42"}
+	costs, err := c.CostByTag("team", q)
+	if err != nil {
+		t.Errorf("CostByTag() returned an error: %s", err)
+		return
+	}
+	expected := []GroupCost{{ID: "payments", Name: "payments", Cost: 12.5}, {ID: "search", Name: "search", Cost: 7.5}}
+	if !reflect.DeepEqual(costs, expected) {
+		t.Errorf("CostByTag() returned %v, expected %v", costs, expected)
+	}
+}
+
+func TestCostQueryTimeMembers(t *testing.T) {
+	from := time.Date(2023, 12, 30, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		q       CostQuery
+		members []string
+	}{
+		{CostQuery{From: from, To: time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)}, []string{"2023-12", "2024-01", "2024-02"}},
+		{CostQuery{From: from, To: from.AddDate(0, 0, 2), Interval: IntervalDaily}, []string{"2023-12-30", "2023-12-31", "2024-01-01"}},
+	}
+	for _, test := range tests {
+		members, err := test.q.timeMembers()
+		if err != nil {
+			t.Errorf("timeMembers() returned an error: %s", err)
+			continue
+		}
+		if !reflect.DeepEqual(members, test.members) {
+			t.Errorf("timeMembers() returned %v, expected %v", members, test.members)
+		}
+	}
+
+	if _, err := (CostQuery{From: from, To: from.AddDate(0, 0, -1)}).timeMembers(); err == nil {
+		t.Errorf("timeMembers() accepted a range ending before it starts")
+	}
+}
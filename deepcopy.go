@@ -0,0 +1,115 @@
+package cloudhealth
+
+// DeepCopy returns a copy of the AWS Account sharing no memory with the original.
+func (a *AwsAccount) DeepCopy() *AwsAccount {
+	if a == nil {
+		return nil
+	}
+	out := *a
+	if a.Billing != nil {
+		billing := *a.Billing
+		out.Billing = &billing
+	}
+	if a.CloudTrail != nil {
+		cloudTrail := *a.CloudTrail
+		out.CloudTrail = &cloudTrail
+	}
+	if a.Status != nil {
+		status := *a.Status
+		out.Status = &status
+	}
+	return &out
+}
+
+// DeepCopy returns a copy of the Perspective sharing no memory with the original,
+// so the copy's rules and constants can be changed freely.
+func (p *Perspective) DeepCopy() *Perspective {
+	if p == nil {
+		return nil
+	}
+	return &Perspective{Schema: *p.Schema.DeepCopy()}
+}
+
+// DeepCopy returns a copy of the Schema sharing no memory with the original.
+func (s *Schema) DeepCopy() *Schema {
+	if s == nil {
+		return nil
+	}
+	out := *s
+	if s.Rules != nil {
+		out.Rules = make([]Rule, len(s.Rules))
+		for i, rule := range s.Rules {
+			out.Rules[i] = rule.deepCopy()
+		}
+	}
+	if s.Constants != nil {
+		out.Constants = make([]Constant, len(s.Constants))
+		for i, constant := range s.Constants {
+			out.Constants[i] = constant.deepCopy()
+		}
+	}
+	if s.Merges != nil {
+		out.Merges = copyJSONValue(s.Merges).([]interface{})
+	}
+	return &out
+}
+
+func (r Rule) deepCopy() Rule {
+	r.Field = copyStrings(r.Field)
+	r.TagField = copyStrings(r.TagField)
+	if r.Condition != nil {
+		condition := *r.Condition
+		if condition.Clauses != nil {
+			condition.Clauses = make([]Clause, len(r.Condition.Clauses))
+			for i, clause := range r.Condition.Clauses {
+				clause.Field = copyStrings(clause.Field)
+				clause.TagField = copyStrings(clause.TagField)
+				condition.Clauses[i] = clause
+			}
+		}
+		r.Condition = &condition
+	}
+	return r
+}
+
+func (c Constant) deepCopy() Constant {
+	if c.List != nil {
+		list := make([]ConstantItem, len(c.List))
+		for i, item := range c.List {
+			if item.BlkID != nil {
+				blkID := *item.BlkID
+				item.BlkID = &blkID
+			}
+			list[i] = item
+		}
+		c.List = list
+	}
+	return c
+}
+
+func copyStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string(nil), s...)
+}
+
+// copyJSONValue deep copies a value decoded from JSON into an interface{}.
+func copyJSONValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = copyJSONValue(item)
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			out[k] = copyJSONValue(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
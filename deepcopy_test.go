@@ -0,0 +1,72 @@
+package cloudhealth
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPerspectiveDeepCopy(t *testing.T) {
+	blkID := "1"
+	original := &Perspective{
+		Schema: Schema{
+			Name: "test",
+			Rules: []Rule{{
+				Type:     "filter",
+				TagField: []string{"Team"},
+				Condition: &Condition{
+					Clauses: []Clause{{TagField: []string{"Environment"}, Op: "=", Val: "prod"}},
+				},
+			}},
+			Constants: []Constant{{
+				Type: DynamicGroupType,
+				List: []ConstantItem{{RefID: "2", BlkID: &blkID}},
+			}},
+			Merges: []interface{}{map[string]interface{}{"to": "1"}},
+		},
+	}
+
+	copied := original.DeepCopy()
+	if !reflect.DeepEqual(original, copied) {
+		t.Errorf("DeepCopy() returned a different perspective:\n%#v\n%#v", copied, original)
+		return
+	}
+
+	copied.Schema.Rules[0].TagField[0] = "changed"
+	copied.Schema.Rules[0].Condition.Clauses[0].Val = "changed"
+	copied.Schema.Rules[0].Condition.Clauses[0].TagField[0] = "changed"
+	*copied.Schema.Constants[0].List[0].BlkID = "changed"
+	copied.Schema.Constants[0].List[0].RefID = "changed"
+	copied.Schema.Merges[0].(map[string]interface{})["to"] = "changed"
+
+	rule := original.Schema.Rules[0]
+	if rule.TagField[0] != "Team" || rule.Condition.Clauses[0].Val != "prod" || rule.Condition.Clauses[0].TagField[0] != "Environment" {
+		t.Errorf("Changing the copy changed the original rules: %#v", rule)
+	}
+	item := original.Schema.Constants[0].List[0]
+	if *item.BlkID != "1" || item.RefID != "2" {
+		t.Errorf("Changing the copy changed the original constants: %#v", item)
+	}
+	if original.Schema.Merges[0].(map[string]interface{})["to"] != "1" {
+		t.Errorf("Changing the copy changed the original merges: %#v", original.Schema.Merges)
+	}
+}
+
+func TestAwsAccountDeepCopy(t *testing.T) {
+	original := &AwsAccount{ID: 1, Name: "test", Billing: &AwsAccountBilling{Bucket: "billing"}, CloudTrail: &AwsAccountCloudTrail{Bucket: "trail"}, Status: &AwsAccountStatus{Level: "green"}}
+	copied := original.DeepCopy()
+	copied.Status.Level = "red"
+	copied.CloudTrail.Bucket = "changed"
+	copied.Billing.Bucket = "changed"
+	if original.Status.Level != "green" {
+		t.Errorf("Changing the copy changed the original status")
+	}
+	if original.Billing.Bucket != "billing" {
+		t.Errorf("Changing the copy changed the original billing configuration")
+	}
+	if original.CloudTrail.Bucket != "trail" {
+		t.Errorf("Changing the copy changed the original CloudTrail configuration")
+	}
+	if (*AwsAccount)(nil).DeepCopy() != nil {
+		t.Errorf("DeepCopy() of nil expected nil")
+	}
+}
@@ -0,0 +1,24 @@
+package cloudhealth
+
+import (
+	"io"
+	"net/url"
+)
+
+// Download retrieves path, relative to the API endpoint and possibly with a query, and copies the response body
+// to w as it arrives instead of buffering it, returning the number of bytes written. It is meant for large
+// exports such as billing artifacts, report payloads and statement CSVs. The Client's Timeout covers the whole
+// transfer. Setting Cache or CoalesceGets buffers responses again, as those read them whole.
+func (s *Client) Download(path string, w io.Writer, opts ...CallOption) (written int64, err error) {
+	defer annotate(&err, "Download", path)
+	ref, err := url.Parse(path)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := s.send(apiCall{method: "GET", path: ref.Path, query: ref.Query(), options: opts})
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return io.Copy(w, resp.Body)
+}
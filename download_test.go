@@ -0,0 +1,65 @@
+package cloudhealth
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDownloadOK(t *testing.T) {
+	payload := strings.Repeat("account,cost\n", 100000)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected ‘GET’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/olap_reports/cost/history" {
+			t.Errorf("Expected request to ‘/olap_reports/cost/history’, got ‘%s’", r.URL.EscapedPath())
+		}
+		if r.URL.Query().Get("interval") != "monthly" || bearerToken(r) != "apiKey" {
+			t.Errorf("Expected the query and the API key to be sent, got ‘%s’", r.URL.RawQuery)
+		}
+		w.Write([]byte(payload))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	var buf bytes.Buffer
+	written, err := c.Download("/olap_reports/cost/history?interval=monthly", &buf)
+	if err != nil {
+		t.Errorf("Download() returned an error: %s", err)
+		return
+	}
+	if written != int64(len(payload)) || buf.String() != payload {
+		t.Errorf("Download() expected to write %d bytes, wrote %d", len(payload), written)
+	}
+}
+
+func TestDownloadUnauthorized(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("unauthorized"))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if _, err := c.Download("/olap_reports/cost/history", &buf); !errors.Is(err, ErrClientAuthenticationError) {
+		t.Errorf("Download() returned the wrong error: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Download() expected not to write the error response, got ‘%s’", buf.String())
+	}
+}
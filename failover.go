@@ -0,0 +1,139 @@
+package cloudhealth
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Failover routes requests to a secondary endpoint, such as an internal mirror or another region, after Threshold
+// consecutive connection failures to the primary EndpointURL. Once Cooldown has elapsed on the secondary, a single
+// request is sent to the primary again, switching back to it when it succeeds. The requests failing to connect
+// are not retried on the other endpoint.
+type Failover struct {
+	URL       *url.URL // secondary endpoint, replacing the EndpointURL and BasePath of the Client
+	Threshold int
+	Cooldown  time.Duration
+	Clock     Clock // defaults to the Clock of the Client sending the request
+
+	mu         sync.Mutex
+	failures   int
+	active     bool
+	switchedAt time.Time
+	probing    bool
+}
+
+// NewFailover returns a Failover to secondaryURL after threshold consecutive connection failures,
+// trying the primary again after cooldown.
+func NewFailover(secondaryURL string, threshold int, cooldown time.Duration) (*Failover, error) {
+	u, err := url.Parse(secondaryURL)
+	if err != nil {
+		return nil, err
+	}
+	return &Failover{
+		URL:       u,
+		Threshold: threshold,
+		Cooldown:  cooldown,
+	}, nil
+}
+
+// Active reports whether requests are currently sent to the secondary endpoint.
+func (f *Failover) Active() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.active
+}
+
+// now returns the time of the failover's Clock, falling back to clock and then to the system clock when nil.
+func (f *Failover) now(clock Clock) time.Time {
+	if f.Clock != nil {
+		clock = f.Clock
+	}
+	if clock == nil {
+		return time.Now()
+	}
+	return clock.Now()
+}
+
+// route reports whether the next request goes to the primary endpoint, and whether it probes the primary.
+// The cool-down is measured with clock when the failover has no Clock.
+func (f *Failover) route(clock Clock) (primary, probe bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.active {
+		return true, false
+	}
+	if f.probing || f.now(clock).Sub(f.switchedAt) < f.Cooldown {
+		return false, false
+	}
+	f.probing = true
+	return true, true
+}
+
+// record updates the failover with the outcome of a request to the primary endpoint, timed with clock when the
+// failover has no Clock.
+func (f *Failover) record(probe, connected bool, clock Clock) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if probe {
+		f.probing = false
+		if connected {
+			f.active = false
+			f.failures = 0
+		} else {
+			f.switchedAt = f.now(clock)
+		}
+		return
+	}
+	if connected {
+		f.failures = 0
+		return
+	}
+	f.failures++
+	if f.Threshold > 0 && f.failures >= f.Threshold && !f.active {
+		f.active = true
+		f.switchedAt = f.now(clock)
+	}
+}
+
+// failoverTransport sends requests to the secondary endpoint of a Failover while it is active.
+type failoverTransport struct {
+	next     http.RoundTripper
+	primary  *url.URL // base URL of the API routes on the primary endpoint
+	failover *Failover
+	clock    Clock // of the Client, used when the failover has none
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	primary, probe := t.failover.route(t.clock)
+	if !primary {
+		return t.next.RoundTrip(t.toSecondary(req))
+	}
+	resp, err := t.next.RoundTrip(req)
+	if !callerGaveUp(req.Context()) {
+		t.failover.record(probe, err == nil, t.clock)
+	} else if probe {
+		t.failover.record(true, false, t.clock)
+	}
+	return resp, err
+}
+
+// toSecondary returns a copy of req addressed to the secondary endpoint.
+func (t *failoverTransport) toSecondary(req *http.Request) *http.Request {
+	out := req.Clone(req.Context())
+	u := *t.failover.URL
+	route := strings.TrimPrefix(req.URL.Path, t.primary.Path)
+	u.Path = path.Join("/", u.Path, route)
+	if strings.HasSuffix(route, "/") && !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	u.RawPath = ""
+	u.RawQuery = req.URL.RawQuery
+	out.URL = &u
+	out.Host = ""
+	return out
+}
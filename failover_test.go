@@ -0,0 +1,136 @@
+package cloudhealth
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFailover(t *testing.T) {
+	primaryUp := false
+	var urls []string
+	next := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		urls = append(urls, req.URL.String())
+		if req.URL.Host == "chapi.cloudhealthtech.com" && !primaryUp {
+			return nil, errors.New("connection refused")
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+	})
+
+	clock := newFakeClock()
+	failover, err := NewFailover("https://mirror.example.com/cloudhealth", 2, time.Minute)
+	if err != nil {
+		t.Errorf("NewFailover() returned an error: %s", err)
+		return
+	}
+	failover.Clock = clock
+	primary, _ := url.Parse("https://chapi.cloudhealthtech.com/v1/")
+	transport := &failoverTransport{next: next, primary: primary, failover: failover}
+	roundTrip := func() error {
+		req, _ := http.NewRequest("GET", "https://chapi.cloudhealthtech.com/v1/aws_accounts/1?page=2", nil)
+		_, err := transport.RoundTrip(req)
+		return err
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := roundTrip(); err == nil {
+			t.Errorf("RoundTrip() expected a connection error while the primary is down")
+		}
+	}
+	if !failover.Active() {
+		t.Errorf("Expected to fail over after 2 connection failures")
+		return
+	}
+	if err := roundTrip(); err != nil {
+		t.Errorf("RoundTrip() returned an error on the secondary endpoint: %s", err)
+	}
+	if last := urls[len(urls)-1]; last != "https://mirror.example.com/cloudhealth/aws_accounts/1?page=2" {
+		t.Errorf("Expected the request to be sent to the secondary endpoint, got %s", last)
+	}
+
+	clock.Advance(time.Minute)
+	if err := roundTrip(); err == nil {
+		t.Errorf("RoundTrip() expected the probe of the primary to fail")
+	}
+	if !failover.Active() {
+		t.Errorf("Expected to stay on the secondary endpoint after a failed probe")
+	}
+
+	primaryUp = true
+	clock.Advance(time.Minute)
+	if err := roundTrip(); err != nil {
+		t.Errorf("RoundTrip() returned an error probing the primary: %s", err)
+	}
+	if failover.Active() || !strings.HasPrefix(urls[len(urls)-1], "https://chapi.cloudhealthtech.com/v1/") {
+		t.Errorf("Expected to switch back to the primary endpoint, got %s", urls[len(urls)-1])
+	}
+}
+
+func TestFailoverClient(t *testing.T) {
+	var hosts []string
+	next := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		hosts = append(hosts, req.URL.Host)
+		if req.URL.Host == "chapi.cloudhealthtech.com" {
+			return nil, errors.New("connection refused")
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+	})
+	c, err := NewClient("apiKey", WithEndpoint("https://chapi.cloudhealthtech.com/v1/"), WithTransport(next))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.Failover, _ = NewFailover("https://mirror.example.com/", 1, time.Minute)
+	clock := newFakeClock()
+	c.Clock = clock
+
+	c.GetAwsExternalID()
+	c.GetAwsExternalID()
+	clock.Advance(time.Minute)
+	c.GetAwsExternalID()
+	expected := []string{"chapi.cloudhealthtech.com", "mirror.example.com", "chapi.cloudhealthtech.com"}
+	if !reflect.DeepEqual(hosts, expected) {
+		t.Errorf("Expected the Failover to measure its cool-down with the Client's Clock, got requests to %v", hosts)
+	}
+	if c.Failover.Clock != nil {
+		t.Errorf("Expected the Client to leave the Failover's Clock unset")
+	}
+}
+
+func TestFailoverOnClientTimeouts(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer primary.Close()
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"generated_external_id":"externalid"}`))
+	}))
+	defer secondary.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(primary.URL), WithTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.Failover, _ = NewFailover(secondary.URL, 1, time.Minute)
+
+	if _, err := c.GetAwsExternalID(); err == nil {
+		t.Errorf("GetAwsExternalID() expected a timeout on the primary endpoint")
+	}
+	if !c.Failover.Active() {
+		t.Errorf("Expected to fail over after the primary endpoint timed out")
+		return
+	}
+	if _, err := c.GetAwsExternalID(); err != nil {
+		t.Errorf("GetAwsExternalID() returned an error on the secondary endpoint: %s", err)
+	}
+}
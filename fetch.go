@@ -0,0 +1,90 @@
+package cloudhealth
+
+import (
+	"context"
+	"sync"
+)
+
+// FetchFunc retrieves a single resource as part of FetchConcurrently.
+type FetchFunc func(ctx context.Context) error
+
+// FetchConcurrently runs fetches concurrently, with at most limit of them in flight at once (no limit when limit <= 0).
+// The first error cancels the context shared by the fetches, stops any fetch not yet started and is returned.
+func FetchConcurrently(ctx context.Context, limit int, fetches ...FetchFunc) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if limit <= 0 {
+		limit = len(fetches)
+	}
+	sem := make(chan struct{}, limit)
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	for _, fetch := range fetches {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			fail(ctx.Err())
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(fetch FetchFunc) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fetch(ctx); err != nil {
+				fail(err)
+			}
+		}(fetch)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// TenantSnapshot is the configuration of a CloudHealth tenant retrieved by GetTenantSnapshot.
+type TenantSnapshot struct {
+	AwsAccounts   []AwsAccount
+	Perspectives  PerspectiveMap
+	AwsExternalID string
+}
+
+// GetTenantSnapshot retrieves all AWS Accounts, all Perspectives and the AWS External ID concurrently,
+// with at most limit requests in flight at once. The first failure cancels the other retrievals, and when
+// CloudHealth throttles one of them the others hold off too, as the Client shares the wait across its calls.
+func (s *Client) GetTenantSnapshot(ctx context.Context, perPage, limit int) (*TenantSnapshot, error) {
+	snapshot := new(TenantSnapshot)
+	err := FetchConcurrently(ctx, limit,
+		func(ctx context.Context) (err error) {
+			snapshot.AwsAccounts, err = s.GetAllAwsAccounts(perPage, WithContext(ctx))
+			return err
+		},
+		func(ctx context.Context) error {
+			perspectives, err := s.GetAllPerspectives(WithContext(ctx))
+			if err != nil {
+				return err
+			}
+			snapshot.Perspectives = *perspectives
+			return nil
+		},
+		func(ctx context.Context) (err error) {
+			snapshot.AwsExternalID, err = s.GetAwsExternalID(WithContext(ctx))
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
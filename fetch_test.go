@@ -0,0 +1,128 @@
+package cloudhealth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetchConcurrentlyLimit(t *testing.T) {
+	var inFlight, maxInFlight int32
+	fetch := func(ctx context.Context) error {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		atomic.AddInt32(&inFlight, -1)
+		return nil
+	}
+
+	err := FetchConcurrently(context.Background(), 2, fetch, fetch, fetch, fetch, fetch)
+	if err != nil {
+		t.Errorf("FetchConcurrently() returned an error: %s", err)
+		return
+	}
+	if maxInFlight > 2 {
+		t.Errorf("FetchConcurrently() expected at most 2 fetches in flight, got %d", maxInFlight)
+	}
+}
+
+func TestFetchConcurrentlyError(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	var canceled int32
+	err := FetchConcurrently(context.Background(), 0,
+		func(ctx context.Context) error {
+			return errFetch
+		},
+		func(ctx context.Context) error {
+			<-ctx.Done()
+			atomic.AddInt32(&canceled, 1)
+			return ctx.Err()
+		},
+	)
+	if err != errFetch {
+		t.Errorf("FetchConcurrently() returned the wrong error: %v", err)
+	}
+	if canceled != 1 {
+		t.Errorf("FetchConcurrently() expected the shared context to be canceled")
+	}
+}
+
+func TestGetTenantSnapshotOK(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body []byte
+		switch r.URL.EscapedPath() {
+		case "/aws_accounts":
+			body, _ = json.Marshal(AwsAccounts{Accounts: []AwsAccount{defaultAWSAccount}})
+		case "/perspective_schemas":
+			body, _ = json.Marshal(defaultPerspectiveMap)
+		case "/aws_accounts/:id/generate_external_id":
+			body, _ = json.Marshal(defaultAwsExternalID)
+		default:
+			t.Errorf("Unexpected request to ‘%s’", r.URL.EscapedPath())
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	snapshot, err := c.GetTenantSnapshot(context.Background(), defaultPerPage, 2)
+	if err != nil {
+		t.Errorf("GetTenantSnapshot() returned an error: %s", err)
+		return
+	}
+	if !sliceAwsAccountsEqual(snapshot.AwsAccounts, []AwsAccount{defaultAWSAccount}) {
+		t.Errorf("GetTenantSnapshot() returned unexpected AWS Accounts: %v", snapshot.AwsAccounts)
+	}
+	if !reflect.DeepEqual(snapshot.Perspectives, defaultPerspectiveMap) {
+		t.Errorf("GetTenantSnapshot() returned unexpected Perspectives: %v", snapshot.Perspectives)
+	}
+	if snapshot.AwsExternalID != defaultAwsExternalID.ExternalID {
+		t.Errorf("GetTenantSnapshot() expected AWS External ID `%s`, got `%s`", defaultAwsExternalID.ExternalID, snapshot.AwsExternalID)
+	}
+}
+
+func TestGetTenantSnapshotCancelsOnError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() == "/aws_accounts/:id/generate_external_id" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		// Listings hang until the failure of the external ID cancels them.
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	start := time.Now()
+	_, err = c.GetTenantSnapshot(context.Background(), defaultPerPage, 3)
+	if !errors.Is(err, ErrClientAuthenticationError) {
+		t.Errorf("GetTenantSnapshot() expected ErrClientAuthenticationError, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the other fetches to be canceled, took %s", elapsed)
+	}
+}
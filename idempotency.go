@@ -0,0 +1,93 @@
+package cloudhealth
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// idempotencyEntry is the outcome of the creates made with an idempotency key.
+type idempotencyEntry struct {
+	mu    sync.Mutex // held during the creates with the key, so that they don't race each other
+	state interface{}
+}
+
+// idempotentCreate runs create for the idempotency key given to the call with WithIdempotencyKey, namespaced by op.
+// Once a create succeeded, a copy of its result is returned again for the same key without sending anything. After a create
+// failed ambiguously, e.g. timing out after CloudHealth may have received it, find is called before creating again,
+// returning the resource matching the request if the earlier attempt created it, or nil.
+// Creates with the same key run one at a time, while creates with different keys run concurrently.
+func idempotentCreate[T any](s *Client, op string, opts []CallOption, find func() (*T, error), create func() (*T, error)) (*T, error) {
+	key := callOptions(opts).idempotencyKey
+	if key == "" {
+		return create()
+	}
+	entry := s.idempotencyEntry(op + " " + key)
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	switch state := entry.state.(type) {
+	case *T:
+		return copyOf(state), nil
+	case ambiguousCreate:
+		found, err := find()
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			entry.state = copyOf(found)
+			return found, nil
+		}
+	}
+
+	created, err := create()
+	switch {
+	case err == nil:
+		entry.state = copyOf(created)
+	case isAmbiguous(err):
+		entry.state = ambiguousCreate{}
+	default:
+		entry.state = nil
+	}
+	return created, err
+}
+
+// copyOf returns a copy of v, so that callers changing a result of idempotentCreate don't change the others.
+// The copy is deep for the types having a DeepCopy method.
+func copyOf[T any](v *T) *T {
+	if c, ok := any(v).(interface{ DeepCopy() *T }); ok {
+		return c.DeepCopy()
+	}
+	out := *v
+	return &out
+}
+
+// idempotencyEntry returns the entry of key, adding it on first use.
+func (s *Client) idempotencyEntry(key string) *idempotencyEntry {
+	s.idempotencyMu.Lock()
+	defer s.idempotencyMu.Unlock()
+	if s.idempotency == nil {
+		s.idempotency = map[string]*idempotencyEntry{}
+	}
+	entry, ok := s.idempotency[key]
+	if !ok {
+		entry = new(idempotencyEntry)
+		s.idempotency[key] = entry
+	}
+	return entry
+}
+
+// isAmbiguous reports whether a create failing with err may nevertheless have been carried out by CloudHealth:
+// the request was sent but no response was received, or a gateway in front of CloudHealth failed or timed out.
+func isAmbiguous(err error) bool {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return true
+	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusBadGateway || apiErr.StatusCode == http.StatusGatewayTimeout)
+}
+
+// ambiguousCreate marks an idempotency key whose create failed without telling whether CloudHealth created the resource.
+type ambiguousCreate struct{}
@@ -0,0 +1,272 @@
+package cloudhealth
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// dropConnection closes the connection of the request without a response, leaving the client unsure whether the
+// request was handled.
+func dropConnection(t *testing.T, w http.ResponseWriter) {
+	conn, _, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		t.Errorf("Unable to hijack the connection: %s", err)
+		return
+	}
+	conn.Close()
+}
+
+func TestCreateAwsAccountIdempotencyKeyAfterDroppedConnection(t *testing.T) {
+	account := AwsAccount{ID: 1, Name: "test", OwnerID: "123456789012", Authentication: NewAssumeRoleAuth("arn:aws:iam::123456789012:role/CloudHealth", "externalid")}
+	posts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			posts++
+			dropConnection(t, w)
+		case "GET":
+			body, _ := json.Marshal(AwsAccounts{Accounts: []AwsAccount{{ID: 2, Name: "other"}, account}})
+			w.Write(body)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if _, err := c.CreateAwsAccount(account, WithIdempotencyKey("onboard-1")); err == nil {
+		t.Errorf("CreateAwsAccount() expected an error for the dropped connection")
+		return
+	}
+	created, err := c.CreateAwsAccount(account, WithIdempotencyKey("onboard-1"))
+	if err != nil {
+		t.Errorf("CreateAwsAccount() returned an error: %s", err)
+		return
+	}
+	if created.ID != account.ID {
+		t.Errorf("CreateAwsAccount() expected the existing AWS Account %d, got %d", account.ID, created.ID)
+	}
+	if posts != 1 {
+		t.Errorf("Expected a single POST, got %d", posts)
+	}
+}
+
+func TestCreateAwsAccountIdempotencyKeyNotFound(t *testing.T) {
+	account := AwsAccount{Name: "test", Authentication: NewAssumeRoleAuth("arn:aws:iam::123456789012:role/CloudHealth", "externalid")}
+	posts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			posts++
+			if posts == 1 {
+				dropConnection(t, w)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			body, _ := json.Marshal(AwsAccount{ID: 1, Name: "test"})
+			w.Write(body)
+		case "GET":
+			body, _ := json.Marshal(AwsAccounts{Accounts: []AwsAccount{{ID: 2, Name: "other"}}})
+			w.Write(body)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	c.CreateAwsAccount(account, WithIdempotencyKey("onboard-1"))
+	created, err := c.CreateAwsAccount(account, WithIdempotencyKey("onboard-1"))
+	if err != nil {
+		t.Errorf("CreateAwsAccount() returned an error: %s", err)
+		return
+	}
+	if created.ID != 1 || posts != 2 {
+		t.Errorf("CreateAwsAccount() expected to create AWS Account 1 with a second POST, got %d after %d POSTs", created.ID, posts)
+	}
+
+	again, err := c.CreateAwsAccount(account, WithIdempotencyKey("onboard-1"))
+	if err != nil {
+		t.Errorf("CreateAwsAccount() returned an error: %s", err)
+		return
+	}
+	if again.ID != 1 || posts != 2 {
+		t.Errorf("CreateAwsAccount() expected AWS Account 1 without another POST, got %d after %d POSTs", again.ID, posts)
+	}
+}
+
+func TestCreatePerspectiveIdempotencyKey(t *testing.T) {
+	posts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			posts++
+			dropConnection(t, w)
+		case "GET":
+			body, _ := json.Marshal(PerspectiveMap{
+				"1":                  PerspectiveStatus{Name: "test", Active: false},
+				defaultPerspectiveID: PerspectiveStatus{Name: "test", Active: true},
+			})
+			w.Write(body)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if _, err := c.CreatePerspective(&defaultPerspective, WithIdempotencyKey("test")); err == nil {
+		t.Errorf("CreatePerspective() expected an error for the dropped connection")
+		return
+	}
+	id, err := c.CreatePerspective(&defaultPerspective, WithIdempotencyKey("test"))
+	if err != nil {
+		t.Errorf("CreatePerspective() returned an error: %s", err)
+		return
+	}
+	if id != defaultPerspectiveID || posts != 1 {
+		t.Errorf("CreatePerspective() expected Perspective %s after a single POST, got %s after %d POSTs", defaultPerspectiveID, id, posts)
+	}
+}
+
+func TestCreateAwsAccountIdempotencyKeyCopiesResult(t *testing.T) {
+	account := AwsAccount{ID: 1, Name: "test", OwnerID: "123456789012", Authentication: NewAssumeRoleAuth("arn:aws:iam::123456789012:role/CloudHealth", "externalid"), Billing: &AwsAccountBilling{Bucket: "billing"}}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(account)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	created, err := c.CreateAwsAccount(account, WithIdempotencyKey("onboard-1"))
+	if err != nil {
+		t.Errorf("CreateAwsAccount() returned an error: %s", err)
+		return
+	}
+	created.Name = "changed"
+	created.Billing.Bucket = "changed"
+	again, err := c.CreateAwsAccount(account, WithIdempotencyKey("onboard-1"))
+	if err != nil {
+		t.Errorf("CreateAwsAccount() returned an error: %s", err)
+		return
+	}
+	again.Name = "changed again"
+	last, err := c.CreateAwsAccount(account, WithIdempotencyKey("onboard-1"))
+	if err != nil {
+		t.Errorf("CreateAwsAccount() returned an error: %s", err)
+		return
+	}
+	if last.Name != "test" || last.Billing.Bucket != "billing" {
+		t.Errorf("CreateAwsAccount() returned a result changed by an earlier caller: %+v", last)
+	}
+}
+
+func TestCreateWithoutIdempotencyKeyPostsAgain(t *testing.T) {
+	posts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posts++
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, "Perspective %d created\n", posts)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	c.CreatePerspective(&defaultPerspective)
+	if id, _ := c.CreatePerspective(&defaultPerspective); id != "2" {
+		t.Errorf("CreatePerspective() expected a second Perspective, got %s", id)
+	}
+}
+
+func TestCreateAwsAccountIdempotencyKeyAfterGatewayTimeout(t *testing.T) {
+	account := AwsAccount{ID: 1, Name: "test", OwnerID: "123456789012", Authentication: NewAssumeRoleAuth("arn:aws:iam::123456789012:role/CloudHealth", "externalid")}
+	posts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			posts++
+			w.WriteHeader(http.StatusGatewayTimeout)
+		case "GET":
+			body, _ := json.Marshal(AwsAccounts{Accounts: []AwsAccount{account}})
+			w.Write(body)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if _, err := c.CreateAwsAccount(account, WithIdempotencyKey("onboard-1")); err == nil {
+		t.Errorf("CreateAwsAccount() expected an error for the gateway timeout")
+		return
+	}
+	created, err := c.CreateAwsAccount(account, WithIdempotencyKey("onboard-1"))
+	if err != nil {
+		t.Errorf("CreateAwsAccount() returned an error: %s", err)
+		return
+	}
+	if created.ID != account.ID || posts != 1 {
+		t.Errorf("CreateAwsAccount() expected the existing AWS Account after a single POST, got %d after %d", created.ID, posts)
+	}
+}
+
+func TestCreateIdempotencyKeysConcurrent(t *testing.T) {
+	inFlight := make(chan struct{}, 2)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inFlight <- struct{}{}
+		// Wait for the create of the other key, which only arrives if keys don't block each other.
+		for len(inFlight) < 2 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(time.Millisecond):
+			}
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":1,"name":"test"}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL), WithTimeout(2*time.Second))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	errs := make(chan error, 2)
+	for _, key := range []string{"onboard-1", "onboard-2"} {
+		go func(key string) {
+			_, err := c.CreateAwsAccount(AwsAccount{Name: "test"}, WithIdempotencyKey(key))
+			errs <- err
+		}(key)
+	}
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("CreateAwsAccount() returned an error: %s", err)
+		}
+	}
+}
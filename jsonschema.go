@@ -0,0 +1,191 @@
+package cloudhealth
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Names of the embedded JSON Schemas describing create and update payloads.
+const (
+	awsAccountPayloadSchema  = "aws_account.json"
+	perspectivePayloadSchema = "perspective.json"
+)
+
+//go:embed schemas/*.json
+var payloadSchemas embed.FS
+
+var (
+	loadedSchemasMu sync.Mutex
+	loadedSchemas   = make(map[string]map[string]interface{})
+)
+
+// ValidationProblem is a single problem found in a request before it was sent to CloudHealth.
+type ValidationProblem struct {
+	Path    string // location of the problem, e.g. "$.schema.rules[0].type"
+	Message string
+}
+
+// ValidationError is returned, without contacting CloudHealth, when a request fails validation.
+type ValidationError struct {
+	Problems []ValidationProblem
+}
+
+// Error implements error.
+func (e *ValidationError) Error() string {
+	problems := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		problems[i] = fmt.Sprintf("%s: %s", p.Path, p.Message)
+	}
+	return fmt.Sprintf("Invalid request: %s", strings.Join(problems, "; "))
+}
+
+// loadPayloadSchema returns the embedded JSON Schema with the given name.
+func loadPayloadSchema(name string) (map[string]interface{}, error) {
+	loadedSchemasMu.Lock()
+	defer loadedSchemasMu.Unlock()
+	if schema, ok := loadedSchemas[name]; ok {
+		return schema, nil
+	}
+	data, err := payloadSchemas.ReadFile("schemas/" + name)
+	if err != nil {
+		return nil, err
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+	loadedSchemas[name] = schema
+	return schema, nil
+}
+
+// validatePayload checks the JSON encoding of payload against the named schema, returning a *ValidationError
+// listing every problem found. Only the subset of JSON Schema used by the embedded schemas is supported:
+// type, enum, required, properties, additionalProperties (false), items and minLength.
+func validatePayload(schemaName string, payload interface{}) error {
+	schema, err := loadPayloadSchema(schemaName)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	var problems []ValidationProblem
+	validateValue(schema, value, "$", &problems)
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+func validateValue(schema map[string]interface{}, value interface{}, path string, problems *[]ValidationProblem) {
+	report := func(format string, args ...interface{}) {
+		*problems = append(*problems, ValidationProblem{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if t, ok := schema["type"]; ok && !matchesType(t, value) {
+		report("expected %s, got %s", typeNames(t), jsonType(value))
+		return
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, allowed := range enum {
+			if allowed == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			report("%v is not one of %v", value, enum)
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		if min, ok := schema["minLength"].(float64); ok && float64(len(v)) < min {
+			report("must be at least %d characters long", int(min))
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				validateValue(items, item, fmt.Sprintf("%s[%d]", path, i), problems)
+			}
+		}
+	case map[string]interface{}:
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, name := range required {
+				if _, ok := v[name.(string)]; !ok {
+					*problems = append(*problems, ValidationProblem{Path: path + "." + name.(string), Message: "is required"})
+				}
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if property, ok := properties[name].(map[string]interface{}); ok {
+				validateValue(property, v[name], path+"."+name, problems)
+			} else if schema["additionalProperties"] == false {
+				*problems = append(*problems, ValidationProblem{Path: path + "." + name, Message: "is not allowed"})
+			}
+		}
+	}
+}
+
+// matchesType reports whether value is of the JSON Schema type t, which may be a name or a list of names.
+func matchesType(t interface{}, value interface{}) bool {
+	if names, ok := t.([]interface{}); ok {
+		for _, name := range names {
+			if matchesType(name, value) {
+				return true
+			}
+		}
+		return false
+	}
+	actual := jsonType(value)
+	return actual == t || (t == "number" && actual == "integer")
+}
+
+func typeNames(t interface{}) string {
+	if names, ok := t.([]interface{}); ok {
+		s := make([]string, len(names))
+		for i, name := range names {
+			s[i] = fmt.Sprint(name)
+		}
+		return strings.Join(s, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+// jsonType returns the JSON Schema type name of a value decoded from JSON.
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == float64(int64(v)) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
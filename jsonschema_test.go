@@ -0,0 +1,87 @@
+package cloudhealth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestValidatePayloadPerspective(t *testing.T) {
+	perspective := Perspective{
+		Schema: Schema{
+			Name:             "test",
+			IncludeInReports: "yes",
+			Rules:            []Rule{{Asset: "AwsAsset"}},
+			Constants:        []Constant{{Type: "Static Group", List: []ConstantItem{{RefID: "1", IsOther: "true"}}}},
+		},
+	}
+
+	err := validatePayload(perspectivePayloadSchema, perspective)
+	verr, ok := err.(*ValidationError)
+	if !ok {
+		t.Errorf("validatePayload() expected a *ValidationError, got %v", err)
+		return
+	}
+	var paths []string
+	for _, p := range verr.Problems {
+		paths = append(paths, p.Path)
+	}
+	expected := []string{"$.schema.include_in_reports", "$.schema.rules[0].type"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("validatePayload() expected problems at %v, got %v", expected, verr.Problems)
+	}
+
+	perspective.Schema.IncludeInReports = "true"
+	perspective.Schema.Rules[0].Type = "filter"
+	if err := validatePayload(perspectivePayloadSchema, perspective); err != nil {
+		t.Errorf("validatePayload() returned an error: %s", err)
+	}
+	if err := validatePayload(perspectivePayloadSchema, defaultPerspective); err != nil {
+		t.Errorf("validatePayload() returned an error: %s", err)
+	}
+}
+
+func TestValidatePayloadsBeforeSending(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected %s request to ‘%s’", r.Method, r.URL.EscapedPath())
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.ValidatePayloads = true
+
+	_, err = c.CreateAwsAccount(AwsAccount{Name: "test", Authentication: AwsAccountAuthentication{Protocol: "password"}})
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Errorf("CreateAwsAccount() expected a *ValidationError, got %v", err)
+		return
+	}
+	if len(verr.Problems) != 1 || verr.Problems[0].Path != "$.authentication.protocol" {
+		t.Errorf("CreateAwsAccount() returned unexpected problems: %v", verr.Problems)
+	}
+}
+
+func TestValidatePayloadsUpdateWithoutAuthentication(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":1,"name":"renamed"}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.ValidatePayloads = true
+
+	// An empty authentication leaves the authentication of the account unchanged.
+	if _, err := c.UpdateAwsAccount(AwsAccount{ID: 1, Name: "renamed"}); err != nil {
+		t.Errorf("UpdateAwsAccount() returned an error: %s", err)
+	}
+}
@@ -0,0 +1,125 @@
+package cloudhealth
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// LintCode identifies the kind of problem reported by LintPerspective.
+type LintCode string
+
+const (
+	// LintUnreachableRule is a rule that never applies, as an earlier rule for the same asset
+	// has no condition or the same condition.
+	LintUnreachableRule LintCode = "unreachable-rule"
+	// LintOverlappingFilters are filter rules sending assets matching the same clause to different groups,
+	// leaving the group of those assets to rule order.
+	LintOverlappingFilters LintCode = "overlapping-filters"
+	// LintEmptyGroup is a static group no filter rule assigns assets to.
+	LintEmptyGroup LintCode = "empty-group"
+	// LintUnknownGroup is a filter rule assigning assets to a group that isn't defined.
+	LintUnknownGroup LintCode = "unknown-group"
+	// LintUnmatchedCategorize is a categorize rule without the dynamic group block constant it refers to.
+	LintUnmatchedCategorize LintCode = "unmatched-categorize"
+)
+
+// LintFinding is a problem found by LintPerspective, located like a ValidationProblem.
+type LintFinding struct {
+	Path    string // e.g. "$.rules[2]"
+	Code    LintCode
+	Message string
+}
+
+func (f LintFinding) String() string {
+	return fmt.Sprintf("%s: %s: %s", f.Path, f.Code, f.Message)
+}
+
+// LintPerspective flags likely mistakes in the schema that CloudHealth accepts, for gating perspective changes in CI.
+// Rules are evaluated in order, the first filter rule matching an asset deciding its group.
+func LintPerspective(schema Schema) []LintFinding {
+	var findings []LintFinding
+	report := func(path string, code LintCode, format string, args ...interface{}) {
+		findings = append(findings, LintFinding{Path: path, Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+
+	groups := map[string]string{} // static group ref_id to its path
+	var groupOrder []string
+	blocks := map[string]bool{} // dynamic group block ref_ids
+	for i, constant := range schema.Constants {
+		for j, item := range constant.List {
+			switch {
+			case constant.Type == StaticGroupType && item.IsOther != "true":
+				groups[item.RefID] = fmt.Sprintf("$.constants[%d].list[%d]", i, j)
+				groupOrder = append(groupOrder, item.RefID)
+			case constant.Type == DynamicGroupBlockType:
+				blocks[item.RefID] = true
+			}
+		}
+	}
+
+	targeted := map[string]bool{}
+	for i, rule := range schema.Rules {
+		path := fmt.Sprintf("$.rules[%d]", i)
+		switch rule.Type {
+		case "filter":
+			targeted[rule.To] = true
+			if _, ok := groups[rule.To]; !ok {
+				report(path, LintUnknownGroup, "assigns %s assets to undefined group %q", rule.Asset, rule.To)
+			}
+		case "categorize":
+			if !blocks[rule.RefID] {
+				report(path, LintUnmatchedCategorize, "refers to dynamic group block %q which has no constant", rule.RefID)
+			}
+		}
+
+		for j, earlier := range schema.Rules[:i] {
+			if earlier.Type != "filter" || earlier.Asset != rule.Asset {
+				continue
+			}
+			if earlier.Condition == nil || reflect.DeepEqual(earlier.Condition, rule.Condition) {
+				report(path, LintUnreachableRule, "%s assets are all matched by $.rules[%d] first", rule.Asset, j)
+				break
+			}
+			if rule.Type == "filter" && earlier.To != rule.To {
+				if clause, ok := sharedClause(earlier.Condition, rule.Condition); ok {
+					report(path, LintOverlappingFilters, "%s assets matching %s %s %q also match $.rules[%d] for another group",
+						rule.Asset, clauseField(clause), clause.Op, clause.Val, j)
+				}
+			}
+		}
+	}
+
+	for _, refID := range groupOrder {
+		if !targeted[refID] {
+			report(groups[refID], LintEmptyGroup, "no filter rule assigns assets to group %q", refID)
+		}
+	}
+	return findings
+}
+
+// sharedClause returns a clause sufficient to match both conditions, if any.
+func sharedClause(a, b *Condition) (Clause, bool) {
+	if a == nil || b == nil || !anyClauseMatches(a) || !anyClauseMatches(b) {
+		return Clause{}, false
+	}
+	for _, ca := range a.Clauses {
+		for _, cb := range b.Clauses {
+			if reflect.DeepEqual(ca, cb) {
+				return ca, true
+			}
+		}
+	}
+	return Clause{}, false
+}
+
+// anyClauseMatches reports whether a single clause is enough to match the condition.
+func anyClauseMatches(c *Condition) bool {
+	return c.CombineWith == "OR" || len(c.Clauses) == 1
+}
+
+func clauseField(c Clause) string {
+	if len(c.TagField) > 0 {
+		return fmt.Sprintf("tag %v", c.TagField)
+	}
+	return fmt.Sprint(c.Field)
+}
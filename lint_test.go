@@ -0,0 +1,62 @@
+package cloudhealth
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLintPerspective(t *testing.T) {
+	prod := &Condition{Clauses: []Clause{{TagField: []string{"Environment"}, Op: "=", Val: "prod"}}}
+	prodOrStaging := &Condition{CombineWith: "OR", Clauses: []Clause{
+		{TagField: []string{"Environment"}, Op: "=", Val: "staging"},
+		{TagField: []string{"Environment"}, Op: "=", Val: "prod"},
+	}}
+	schema := Schema{
+		Name: "Environment",
+		Rules: []Rule{
+			{Type: "filter", Asset: "AwsInstance", To: "1", Condition: prod},
+			{Type: "filter", Asset: "AwsInstance", To: "2", Condition: prodOrStaging},
+			{Type: "filter", Asset: "AwsInstance", To: "1", Condition: prod},
+			{Type: "filter", Asset: "AwsRdsInstance", To: "9"},
+			{Type: "categorize", Asset: "AwsAsset", RefID: "100", TagField: []string{"Team"}},
+		},
+		Constants: []Constant{
+			{Type: StaticGroupType, List: []ConstantItem{
+				{RefID: "1", Name: "prod"},
+				{RefID: "2", Name: "staging"},
+				{RefID: "3", Name: "dev"},
+				{RefID: "4", Name: "Other", IsOther: "true"},
+			}},
+		},
+	}
+
+	findings := LintPerspective(schema)
+	var got []LintCode
+	var paths []string
+	for _, f := range findings {
+		got = append(got, f.Code)
+		paths = append(paths, f.Path)
+	}
+	expected := []LintCode{LintOverlappingFilters, LintUnreachableRule, LintUnknownGroup, LintUnmatchedCategorize, LintEmptyGroup}
+	expectedPaths := []string{"$.rules[1]", "$.rules[2]", "$.rules[3]", "$.rules[4]", "$.constants[0].list[2]"}
+	if !reflect.DeepEqual(got, expected) || !reflect.DeepEqual(paths, expectedPaths) {
+		t.Errorf("LintPerspective() returned unexpected findings:\n%v", findings)
+	}
+}
+
+func TestLintPerspectiveClean(t *testing.T) {
+	schema := Schema{
+		Rules: []Rule{
+			{Type: "filter", Asset: "AwsInstance", To: "1", Condition: &Condition{Clauses: []Clause{{TagField: []string{"Environment"}, Op: "=", Val: "prod"}}}},
+			{Type: "filter", Asset: "AwsInstance", To: "2"},
+			{Type: "categorize", Asset: "AwsAsset", RefID: "100", TagField: []string{"Team"}},
+		},
+		Constants: []Constant{
+			{Type: StaticGroupType, List: []ConstantItem{{RefID: "1", Name: "prod"}, {RefID: "2", Name: "rest"}}},
+			{Type: DynamicGroupBlockType, List: []ConstantItem{{RefID: "100", Name: "Team"}}},
+		},
+	}
+	if findings := LintPerspective(schema); len(findings) != 0 {
+		t.Errorf("LintPerspective() expected no findings, got:\n%v", findings)
+	}
+}
@@ -0,0 +1,47 @@
+package cloudhealth
+
+import (
+	"strings"
+	"time"
+)
+
+// MetricsCollector records metrics of the requests sent to CloudHealth, such as Prometheus counters and histograms.
+// Route is the URL path with IDs replaced by ":id", e.g. "/aws_accounts/:id", keeping label cardinality low.
+// Status is zero when no response was received, err being set instead.
+type MetricsCollector interface {
+	ObserveRequest(method, route string, status int, latency time.Duration, err error)
+}
+
+// WithMetrics reports every request to collector, after calling any StatsHook already set.
+func WithMetrics(collector MetricsCollector) ClientOption {
+	return func(s *Client) error {
+		s.addStatsHook(func(stats RequestStats) {
+			collector.ObserveRequest(stats.Method, routeOf(stats.Endpoint), stats.StatusCode, stats.Latency, stats.Err)
+		})
+		return nil
+	}
+}
+
+// addStatsHook calls hook with the statistics of every request, after the StatsHook already set.
+func (s *Client) addStatsHook(hook func(RequestStats)) {
+	previous := s.StatsHook
+	if previous == nil {
+		s.StatsHook = hook
+		return
+	}
+	s.StatsHook = func(stats RequestStats) {
+		previous(stats)
+		hook(stats)
+	}
+}
+
+// routeOf returns path with its numeric segments, the IDs of CloudHealth resources, replaced by ":id".
+func routeOf(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment != "" && strings.Trim(segment, "0123456789") == "" {
+			segments[i] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
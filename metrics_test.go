@@ -0,0 +1,57 @@
+package cloudhealth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// recordingCollector is a MetricsCollector keeping the observations it receives.
+type recordingCollector struct {
+	observations []string
+}
+
+func (c *recordingCollector) ObserveRequest(method, route string, status int, latency time.Duration, err error) {
+	c.observations = append(c.observations, method+" "+route+" "+http.StatusText(status))
+}
+
+func TestWithMetrics(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"id":1,"name":"test"}`))
+	}))
+	defer ts.Close()
+
+	collector := new(recordingCollector)
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL), WithMetrics(collector))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.GetAwsAccount(1)
+	c.DeleteAwsAccount(2)
+
+	expected := []string{"GET /aws_accounts/:id OK", "DELETE /aws_accounts/:id Not Found"}
+	if !reflect.DeepEqual(collector.observations, expected) {
+		t.Errorf("Expected observations %v, got %v", expected, collector.observations)
+	}
+}
+
+func TestRouteOf(t *testing.T) {
+	tests := map[string]string{
+		"/aws_accounts":                                     "/aws_accounts",
+		"/aws_accounts/1234":                                "/aws_accounts/:id",
+		"/aws_accounts/:id/generate_external_id":            "/aws_accounts/:id/generate_external_id",
+		"/cloudhealth/v1/perspective_schemas/1234567839263": "/cloudhealth/v1/perspective_schemas/:id",
+	}
+	for path, expected := range tests {
+		if route := routeOf(path); route != expected {
+			t.Errorf("routeOf(%s) expected %s, got %s", path, expected, route)
+		}
+	}
+}
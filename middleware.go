@@ -0,0 +1,49 @@
+package cloudhealth
+
+import "net/http"
+
+// Middleware wraps the RoundTripper used to send requests to CloudHealth, e.g. to stamp headers,
+// add custom authentication or inject faults for testing.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to an http.RoundTripper, which is convenient for writing Middleware.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper.
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// chainMiddleware wraps transport with middleware, the first Middleware being the outermost.
+func chainMiddleware(transport http.RoundTripper, middleware []Middleware) http.RoundTripper {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		transport = middleware[i](transport)
+	}
+	return transport
+}
+
+// Hooks are functions called around every request sent to CloudHealth, including each retry, for integrations
+// that only observe or decorate requests and don't need to write a Middleware.
+type Hooks struct {
+	// OnRequest is called before the request is sent and may modify its headers.
+	OnRequest func(req *http.Request)
+	// OnResponse is called with the response, or the error when none was received.
+	OnResponse func(req *http.Request, resp *http.Response, err error)
+}
+
+// Middleware returns the Middleware calling the hooks.
+func (h Hooks) Middleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if h.OnRequest != nil {
+				req = req.Clone(req.Context())
+				h.OnRequest(req)
+			}
+			resp, err := next.RoundTrip(req)
+			if h.OnResponse != nil {
+				h.OnResponse(req, resp, err)
+			}
+			return resp, err
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package cloudhealth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header["X-Trace"]; !reflect.DeepEqual(got, []string{"outer", "inner"}) {
+			t.Errorf("Expected middleware headers in order [outer inner], got %v", got)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	stamp := func(value string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				req.Header.Add("X-Trace", value)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.Middleware = []Middleware{stamp("outer"), stamp("inner")}
+
+	if err := c.DeleteAwsAccount(defaultAWSAccount.ID); err != nil {
+		t.Errorf("DeleteAwsAccount() returned an error: %s", err)
+	}
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	c, err := NewClient("apiKey", WithEndpoint("https://api.foo.bar"))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.Middleware = []Middleware{
+		func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				rec := httptest.NewRecorder()
+				rec.WriteHeader(http.StatusNotFound)
+				return rec.Result(), nil
+			})
+		},
+	}
+
+	if err := c.DeleteAwsAccount(defaultAWSAccount.ID); !errors.Is(err, ErrAwsAccountNotFound) {
+		t.Errorf("DeleteAwsAccount() returned the wrong error: %v", err)
+	}
+}
+
+func TestHooks(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Tenant") != "acme" {
+			t.Errorf("Expected the X-Tenant header set by OnRequest, got ‘%s’", r.Header.Get("X-Tenant"))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	var statuses []int
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL), WithHooks(Hooks{
+		OnRequest: func(req *http.Request) {
+			req.Header.Set("X-Tenant", "acme")
+		},
+		OnResponse: func(req *http.Request, resp *http.Response, err error) {
+			if err != nil {
+				t.Errorf("OnResponse() called with an error: %s", err)
+				return
+			}
+			statuses = append(statuses, resp.StatusCode)
+		},
+	}))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if err := c.DeleteAwsAccount(defaultAWSAccount.ID); err != nil {
+		t.Errorf("DeleteAwsAccount() returned an error: %s", err)
+	}
+	if !reflect.DeepEqual(statuses, []int{http.StatusOK}) {
+		t.Errorf("Expected OnResponse() to be called with the 200 response, got %v", statuses)
+	}
+}
+
+func TestWithMiddlewareAppends(t *testing.T) {
+	noop := func(next http.RoundTripper) http.RoundTripper { return next }
+	c, err := NewClient("apiKey", WithMiddleware(noop), WithMiddleware(noop, noop))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if len(c.Middleware) != 3 {
+		t.Errorf("WithMiddleware() expected 3 middleware, got %d", len(c.Middleware))
+	}
+}
@@ -0,0 +1,99 @@
+package cloudhealth
+
+import "strconv"
+
+// PortablePerspective returns a copy of the Perspective that can be created in another tenant: the "Other" groups and
+// dynamic groups CloudHealth generates are left out, and the ref_ids of groups and dynamic group blocks are
+// renumbered from 1, with the rules updated to match.
+func PortablePerspective(p *Perspective) *Perspective {
+	out := p.DeepCopy()
+	schema := &out.Schema
+
+	refIDs := map[string]string{}
+	constants := []Constant{}
+	for _, constant := range schema.Constants {
+		if constant.Type == DynamicGroupType {
+			continue
+		}
+		list := []ConstantItem{}
+		for _, item := range constant.List {
+			if item.IsOther == "true" {
+				continue
+			}
+			if item.RefID != "" {
+				if _, ok := refIDs[item.RefID]; !ok {
+					refIDs[item.RefID] = strconv.Itoa(len(refIDs) + 1)
+				}
+				item.RefID = refIDs[item.RefID]
+			}
+			list = append(list, item)
+		}
+		constant.List = list
+		constants = append(constants, constant)
+	}
+	schema.Constants = constants
+
+	for i := range schema.Rules {
+		rule := &schema.Rules[i]
+		if to, ok := refIDs[rule.To]; ok {
+			rule.To = to
+		}
+		if refID, ok := refIDs[rule.RefID]; ok {
+			rule.RefID = refID
+		}
+	}
+	return out
+}
+
+// PerspectiveCopy is the outcome of copying a Perspective with CopyPerspectives.
+type PerspectiveCopy struct {
+	SourceID string
+	Name     string
+	TargetID string
+	Created  bool // false when an existing Perspective with the same name was updated
+	Err      error
+}
+
+// CopyPerspectives copies the Perspectives with the given IDs from the src Client's tenant to the dst Client's,
+// as made portable by PortablePerspective. A Perspective replaces the active Perspective of the same name in the
+// target tenant, or is created when there is none. Every Perspective is attempted, failures being reported in the results,
+// unless the context of opts is done, which stops the copy. opts, such as WithContext or WithCallTimeout, apply to the
+// requests to both tenants.
+func CopyPerspectives(src, dst *Client, ids []string, opts ...CallOption) ([]PerspectiveCopy, error) {
+	existing, err := dst.GetAllPerspectives(opts...)
+	if err != nil {
+		return nil, err
+	}
+	byName := map[string]string{}
+	for id, status := range *existing {
+		if status.Active {
+			byName[status.Name] = id
+		}
+	}
+
+	ctx := callOptions(opts).ctx
+	results := make([]PerspectiveCopy, 0, len(ids))
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		result := PerspectiveCopy{SourceID: id}
+		perspective, err := src.GetPerspective(id, opts...)
+		if err != nil {
+			result.Err = err
+			results = append(results, result)
+			continue
+		}
+		portable := PortablePerspective(perspective)
+		result.Name = portable.Schema.Name
+		if targetID, ok := byName[result.Name]; ok {
+			result.TargetID = targetID
+			_, result.Err = dst.UpdatePerspective(targetID, portable, opts...)
+		} else {
+			result.Created = true
+			result.TargetID, result.Err = dst.CreatePerspective(portable, opts...)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
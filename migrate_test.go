@@ -0,0 +1,120 @@
+package cloudhealth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestPortablePerspective(t *testing.T) {
+	p := &Perspective{Schema: Schema{
+		Name:             "Environment",
+		IncludeInReports: "true",
+		Rules: []Rule{
+			{Type: "filter", Asset: "AwsInstance", To: "1649267441730"},
+			{Type: "categorize", Asset: "AwsAsset", RefID: "1649267441740", TagField: []string{"Team"}},
+		},
+		Constants: []Constant{
+			{Type: StaticGroupType, List: []ConstantItem{
+				{RefID: "1649267441730", Name: "prod"},
+				{RefID: "1649267441731", Name: "Other", IsOther: "true"},
+			}},
+			{Type: DynamicGroupBlockType, List: []ConstantItem{{RefID: "1649267441740", Name: "Team"}}},
+			{Type: DynamicGroupType, List: []ConstantItem{{RefID: "1649267441741", Name: "platform"}}},
+		},
+	}}
+
+	portable := PortablePerspective(p)
+	expected := Schema{
+		Name:             "Environment",
+		IncludeInReports: "true",
+		Rules: []Rule{
+			{Type: "filter", Asset: "AwsInstance", To: "1"},
+			{Type: "categorize", Asset: "AwsAsset", RefID: "2", TagField: []string{"Team"}},
+		},
+		Constants: []Constant{
+			{Type: StaticGroupType, List: []ConstantItem{{RefID: "1", Name: "prod"}}},
+			{Type: DynamicGroupBlockType, List: []ConstantItem{{RefID: "2", Name: "Team"}}},
+		},
+	}
+	if !reflect.DeepEqual(portable.Schema, expected) {
+		t.Errorf("PortablePerspective() expected\n%+v\ngot\n%+v", expected, portable.Schema)
+	}
+	if p.Schema.Rules[0].To != "1649267441730" {
+		t.Errorf("PortablePerspective() changed the original Perspective")
+	}
+}
+
+func TestCopyPerspectives(t *testing.T) {
+	src := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := map[string]string{"/perspective_schemas/10": "Environment", "/perspective_schemas/11": "Team"}[r.URL.Path]
+		if name == "" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, _ := json.Marshal(Perspective{Schema: Schema{Name: name, IncludeInReports: "true"}})
+		w.Write(body)
+	}))
+	defer src.Close()
+
+	var requests []string
+	dst := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case "GET":
+			body, _ := json.Marshal(PerspectiveMap{"20": {Name: "Environment", Active: true}})
+			w.Write(body)
+		case "PUT":
+			w.Write([]byte(`{"schema":{"name":"Environment"}}`))
+		case "POST":
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, "Perspective 21 created")
+		}
+	}))
+	defer dst.Close()
+
+	srcClient, _ := NewClient("srcKey", WithEndpoint(src.URL))
+	dstClient, _ := NewClient("dstKey", WithEndpoint(dst.URL))
+	results, err := CopyPerspectives(srcClient, dstClient, []string{"10", "11", "12"})
+	if err != nil {
+		t.Errorf("CopyPerspectives() returned an error: %s", err)
+		return
+	}
+	if len(results) != 3 {
+		t.Errorf("CopyPerspectives() expected 3 results, got %+v", results)
+		return
+	}
+	if r := results[0]; r.Err != nil || r.Created || r.TargetID != "20" {
+		t.Errorf("CopyPerspectives() expected Environment to update Perspective 20, got %+v", r)
+	}
+	if r := results[1]; r.Err != nil || !r.Created || r.TargetID != "21" {
+		t.Errorf("CopyPerspectives() expected Team to be created as Perspective 21, got %+v", r)
+	}
+	if r := results[2]; r.Err == nil {
+		t.Errorf("CopyPerspectives() expected an error for a missing Perspective, got %+v", r)
+	}
+	expected := []string{"GET /perspective_schemas", "PUT /perspective_schemas/20", "POST /perspective_schemas/"}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("CopyPerspectives() expected requests %v, got %v", expected, requests)
+	}
+}
+
+func TestCopyPerspectivesCanceled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request with a canceled context, got ‘%s %s’", r.Method, r.URL.Path)
+	}))
+	defer ts.Close()
+
+	srcClient, _ := NewClient("srcKey", WithEndpoint(ts.URL))
+	dstClient, _ := NewClient("dstKey", WithEndpoint(ts.URL))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := CopyPerspectives(srcClient, dstClient, []string{"10"}, WithContext(ctx)); !errors.Is(err, context.Canceled) {
+		t.Errorf("CopyPerspectives() expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,34 @@
+package cloudhealth
+
+import "fmt"
+
+// OperationError annotates an error with the Client method and the resource it was called for,
+// so failures from concurrent bulk jobs can be attributed. The original error is available with
+// errors.Is and errors.As.
+type OperationError struct {
+	Op       string // Client method, e.g. "UpdateAwsAccount"
+	Resource string // identifier of the resource, empty for listings
+	Err      error
+}
+
+// Error implements error.
+func (e *OperationError) Error() string {
+	return fmt.Sprintf("%s(%s): %s", e.Op, e.Resource, e.Err)
+}
+
+// Unwrap returns the annotated error.
+func (e *OperationError) Unwrap() error {
+	return e.Err
+}
+
+// annotate wraps *err, when set, in an OperationError for op on resource. It is meant to be deferred.
+func annotate(err *error, op string, resource interface{}) {
+	if *err == nil {
+		return
+	}
+	*err = &OperationError{
+		Op:       op,
+		Resource: fmt.Sprint(resource),
+		Err:      *err,
+	}
+}
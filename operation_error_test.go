@@ -0,0 +1,46 @@
+package cloudhealth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOperationError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	_, err = c.UpdateAwsAccount(defaultAWSAccount)
+	var opErr *OperationError
+	if !errors.As(err, &opErr) {
+		t.Errorf("UpdateAwsAccount() expected an *OperationError, got %v", err)
+		return
+	}
+	if opErr.Op != "UpdateAwsAccount" || opErr.Resource != "1234567890" {
+		t.Errorf("UpdateAwsAccount() returned unexpected operation %q and resource %q", opErr.Op, opErr.Resource)
+	}
+	if !errors.Is(err, ErrAwsAccountNotFound) {
+		t.Errorf("UpdateAwsAccount() expected to wrap ErrAwsAccountNotFound, got %v", err)
+	}
+	if expected := "UpdateAwsAccount(1234567890): AWS Account not found ("; !strings.HasPrefix(err.Error(), expected) {
+		t.Errorf("Error() expected to start with %q, got %q", expected, err.Error())
+	}
+}
+
+func TestOperationErrorNil(t *testing.T) {
+	var err error
+	annotate(&err, "GetAwsAccount", 1)
+	if err != nil {
+		t.Errorf("annotate() wrapped a nil error: %v", err)
+	}
+}
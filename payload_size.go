@@ -0,0 +1,14 @@
+package cloudhealth
+
+import "fmt"
+
+// PayloadTooLargeError is returned without contacting CloudHealth when a request body exceeds the size the API accepts.
+type PayloadTooLargeError struct {
+	Size  int64 // bytes of the encoded body
+	Limit int64
+}
+
+// Error implements error.
+func (e *PayloadTooLargeError) Error() string {
+	return fmt.Sprintf("Request body of %d bytes exceeds the limit of %d bytes", e.Size, e.Limit)
+}
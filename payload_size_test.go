@@ -0,0 +1,72 @@
+package cloudhealth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// largePerspective returns a Perspective whose rules serialize beyond DefaultMaxPerspectiveSize.
+func largePerspective() *Perspective {
+	perspective := &Perspective{Schema: Schema{Name: "tags", IncludeInReports: "true"}}
+	for i := 0; i < DefaultMaxPerspectiveSize/50; i++ {
+		perspective.Schema.Rules = append(perspective.Schema.Rules, Rule{
+			Type:  "filter",
+			Asset: "AwsAsset",
+			To:    fmt.Sprint(i),
+			Condition: &Condition{Clauses: []Clause{{
+				TagField: []string{"team"},
+				Op:       "=",
+				Val:      fmt.Sprintf("team-%d", i),
+			}}},
+		})
+	}
+	return perspective
+}
+
+func TestPerspectivePayloadTooLarge(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request to be sent, got ‘%s %s’", r.Method, r.URL.EscapedPath())
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	perspective := largePerspective()
+	var tooLarge *PayloadTooLargeError
+	if _, err := c.CreatePerspective(perspective); !errors.As(err, &tooLarge) {
+		t.Errorf("CreatePerspective() expected a *PayloadTooLargeError, got %v", err)
+		return
+	}
+	if tooLarge.Size <= DefaultMaxPerspectiveSize || tooLarge.Limit != DefaultMaxPerspectiveSize {
+		t.Errorf("CreatePerspective() reported an unexpected size: %s", tooLarge)
+	}
+	if _, err := c.UpdatePerspective(defaultPerspectiveID, perspective); !errors.As(err, &tooLarge) {
+		t.Errorf("UpdatePerspective() expected a *PayloadTooLargeError, got %v", err)
+	}
+}
+
+func TestPerspectivePayloadSizeLimitDisabled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"message":"Perspective 1234567890 created"}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.MaxPerspectiveSize = -1
+
+	if _, err := c.CreatePerspective(largePerspective()); err != nil {
+		t.Errorf("CreatePerspective() returned an error without a size limit: %s", err)
+	}
+}
@@ -1,7 +1,7 @@
 package cloudhealth
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,7 +9,6 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
-	"time"
 )
 
 // Clause represents clauses for matching the rules
@@ -85,7 +84,24 @@ var emptyPerspective = Perspective{
 }
 
 // ErrPerspectiveNotFound is returned when a Perspective doesn't exist on Read
-var ErrPerspectiveNotFound = errors.New("Perspective not found")
+var ErrPerspectiveNotFound error = notFoundError("Perspective not found")
+
+var perspectiveSchemas = resource[Perspective]{path: "perspective_schemas", notFound: ErrPerspectiveNotFound, schema: perspectivePayloadSchema, maxBody: (*Client).maxPerspectiveSize}
+
+// DefaultMaxPerspectiveSize is the MaxPerspectiveSize of Clients that don't set one. It isn't a limit documented
+// by CloudHealth, but a guard against Perspectives that large being rejected with an opaque API failure.
+const DefaultMaxPerspectiveSize = 1 << 20
+
+// maxPerspectiveSize returns the largest serialized Perspective the Client sends, zero when it has no limit.
+func (s *Client) maxPerspectiveSize() int64 {
+	switch {
+	case s.MaxPerspectiveSize < 0:
+		return 0
+	case s.MaxPerspectiveSize == 0:
+		return DefaultMaxPerspectiveSize
+	}
+	return s.MaxPerspectiveSize
+}
 
 type Group map[string]interface{}
 
@@ -106,96 +122,128 @@ func (p *Perspective) Empty() bool {
 	return s.Name == "Empty" && s.IncludeInReports == "false" && len(s.Rules) == 0 && len(s.Merges) == 0 && len(s.Constants) == 0
 }
 
-func (s *Client) GetAllPerspectives() (*PerspectiveMap, error) {
-	relativeURL, _ := url.Parse(fmt.Sprintf("perspective_schemas?api_key=%s", s.ApiKey))
-	url := s.EndpointURL.ResolveReference(relativeURL)
-
-	req, err := http.NewRequest("GET", url.String(), nil)
-
-	client := &http.Client{
-		Timeout: time.Second * time.Duration(s.Timeout),
+// defaultPerspectivesPerPage is the page size GetAllPerspectives lists Perspectives with.
+const defaultPerspectivesPerPage = 100
+
+// PerspectivesPageFunc is called with each page of Perspectives retrieved by GetPerspectivesPages.
+// Returning false stops the iteration after the current page.
+type PerspectivesPageFunc func(page PerspectiveMap) bool
+
+// GetAllPerspectives gets the ID, name and state of all Perspectives, retrieving them page by page.
+// When the listing is interrupted by the context given WithContext, the Perspectives retrieved so far are
+// returned along with a *ListInterruptedError.
+func (s *Client) GetAllPerspectives(opts ...CallOption) (perspectives *PerspectiveMap, err error) {
+	defer annotate(&err, "GetAllPerspectives", "")
+	all := PerspectiveMap{}
+	err = s.perspectivesPages(defaultPerspectivesPerPage, func(page PerspectiveMap) bool {
+		for id, status := range page {
+			all[id] = status
+		}
+		return true
+	}, opts)
+	var interrupted *ListInterruptedError
+	if errors.As(err, &interrupted) {
+		return &all, err
 	}
-	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	return &all, nil
+}
 
-	responseBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
+// GetPerspectivesPages iterates over the Perspectives one page at a time, calling fn for each page.
+func (s *Client) GetPerspectivesPages(perPage int, fn PerspectivesPageFunc, opts ...CallOption) (err error) {
+	defer annotate(&err, "GetPerspectivesPages", "")
+	return s.perspectivesPages(perPage, fn, opts)
+}
 
-	switch resp.StatusCode {
-	case http.StatusOK:
-		var perspectives = new(PerspectiveMap)
-		err = json.Unmarshal(responseBody, &perspectives)
-		if err != nil {
-			return nil, err
+// perspectivesPages lists the Perspectives like awsAccountsPages. As the listing is a map keyed by ID, it also
+// stops at a page holding no new Perspective, in case CloudHealth answers every page with the whole listing.
+func (s *Client) perspectivesPages(perPage int, fn PerspectivesPageFunc, opts []CallOption) error {
+	seen := map[string]bool{}
+	var current PerspectiveMap
+	return listPages(s, perspectiveSchemas, perPage, func(page *PerspectiveMap) []string {
+		current = *page
+		ids := make([]string, 0, len(*page))
+		for id := range *page {
+			ids = append(ids, id)
 		}
-		return perspectives, nil
-	case http.StatusUnauthorized:
-		return nil, ErrClientAuthenticationError
-	default:
-		return nil, fmt.Errorf("Unknown Response with CloudHealth: `%d`", resp.StatusCode)
-	}
+		return ids
+	}, func(ids []string) bool {
+		page := PerspectiveMap{}
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				page[id] = current[id]
+			}
+		}
+		return len(page) > 0 && fn(page)
+	}, opts)
 }
 
-func (s *Client) GetPerspective(id string) (*Perspective, error) {
-	relativeURL, _ := url.Parse(fmt.Sprintf("perspective_schemas/%s?api_key=%s", id, s.ApiKey))
-	url := s.EndpointURL.ResolveReference(relativeURL)
-
-	req, err := http.NewRequest("GET", url.String(), nil)
+func (s *Client) GetPerspective(id string, opts ...CallOption) (perspective *Perspective, err error) {
+	defer annotate(&err, "GetPerspective", id)
+	return s.getPerspective(id, opts)
+}
 
-	client := &http.Client{
-		Timeout: time.Second * time.Duration(s.Timeout),
-	}
-	resp, err := client.Do(req)
+func (s *Client) getPerspective(id string, opts []CallOption) (*Perspective, error) {
+	perspective, err := perspectiveSchemas.get(s, id, opts)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	if perspective.Empty() {
+		return nil, ErrPerspectiveNotFound
+	}
+	return perspective, nil
+}
 
-	responseBody, err := ioutil.ReadAll(resp.Body)
+func (s *Client) CreatePerspective(perspective *Perspective, opts ...CallOption) (id string, err error) {
+	defer annotate(&err, "CreatePerspective", perspective.Schema.Name)
+	if err := perspective.Validate(); err != nil {
+		return "", err
+	}
+	created, err := idempotentCreate(s, "CreatePerspective", opts, func() (*string, error) {
+		return s.findPerspective(perspective.Schema.Name, opts)
+	}, func() (*string, error) {
+		id, err := s.createPerspective(perspective, opts)
+		return &id, err
+	})
 	if err != nil {
-		return nil, err
+		return "", err
 	}
+	return *created, nil
+}
 
-	switch resp.StatusCode {
-	case http.StatusOK:
-		var perspective = new(Perspective)
-		err = json.Unmarshal(responseBody, &perspective)
-		if err != nil {
-			return nil, err
-		}
-		if perspective.Empty() {
-			return nil, ErrPerspectiveNotFound
+// findPerspective returns the ID of the active Perspective with the given name, or nil.
+func (s *Client) findPerspective(name string, opts []CallOption) (*string, error) {
+	var found *string
+	err := s.perspectivesPages(defaultPerspectivesPerPage, func(page PerspectiveMap) bool {
+		for id, status := range page {
+			if status.Active && status.Name == name {
+				found = &id
+				return false
+			}
 		}
-		return perspective, nil
-	case http.StatusUnauthorized:
-		return nil, ErrClientAuthenticationError
-	case http.StatusNotFound:
-		return nil, ErrPerspectiveNotFound
-	default:
-		return nil, fmt.Errorf("Unknown Response with CloudHealth: `%d`", resp.StatusCode)
-	}
+		return true
+	}, opts)
+	return found, err
 }
 
-func (s *Client) CreatePerspective(perspective *Perspective) (string, error) {
-
-	body, _ := json.Marshal(perspective)
-
-	relativeURL, _ := url.Parse(fmt.Sprintf("perspective_schemas/?api_key=%s", s.ApiKey))
-	url := s.EndpointURL.ResolveReference(relativeURL)
-
-	req, err := http.NewRequest("POST", url.String(), bytes.NewBuffer(body))
-
-	req.Header.Add("Content-Type", "application/json")
-
-	client := &http.Client{
-		Timeout: time.Second * time.Duration(s.Timeout),
-	}
-	resp, err := client.Do(req)
+// perspectiveCreatedPattern extracts the ID of a created Perspective from the message CloudHealth responds with.
+var perspectiveCreatedPattern = regexp.MustCompile(`Perspective (\d*) created`)
+
+func (s *Client) createPerspective(perspective *Perspective, opts []CallOption) (string, error) {
+	resp, err := s.send(apiCall{
+		method:   "POST",
+		path:     perspectiveSchemas.path + "/",
+		body:     perspective,
+		schema:   perspectiveSchemas.schema,
+		maxBody:  perspectiveSchemas.maxBodyOf(s),
+		ok:       []int{http.StatusOK, http.StatusCreated},
+		notFound: ErrPerspectiveNotFound,
+		errorFor: unknownPerspectiveResponse(perspective),
+		options:  opts,
+	})
 	if err != nil {
 		return "", err
 	}
@@ -206,113 +254,65 @@ func (s *Client) CreatePerspective(perspective *Perspective) (string, error) {
 		return "", err
 	}
 
-	switch resp.StatusCode {
-	case http.StatusOK, http.StatusCreated:
-		re := regexp.MustCompile(`Perspective (\d*) created`)
-		match := re.FindStringSubmatch(string(responseBody))
-		if match == nil || len(match) != 2 {
-			return "", fmt.Errorf("Created perspective but didn't understand response to extract ID: %s", responseBody)
-		}
-		return match[1], nil
-	case http.StatusUnauthorized:
-		return "", ErrClientAuthenticationError
-	case http.StatusNotFound:
-		return "", ErrPerspectiveNotFound
-	default:
-		return "", fmt.Errorf("Unknown Response with CloudHealth: `%d` when sending:\n%v", resp.StatusCode, string(body))
+	match := perspectiveCreatedPattern.FindStringSubmatch(string(responseBody))
+	if match == nil || len(match) != 2 {
+		return "", fmt.Errorf("Created perspective but didn't understand response to extract ID: %s", responseBody)
 	}
+	return match[1], nil
 }
 
-func (s *Client) UpdatePerspective(perspectiveID string, perspective *Perspective) (*Perspective, error) {
-
-	relativeURL, _ := url.Parse(fmt.Sprintf("perspective_schemas/%s?api_key=%s", perspectiveID, s.ApiKey))
-	url := s.EndpointURL.ResolveReference(relativeURL)
-
-	body, _ := json.Marshal(perspective)
-
-	req, err := http.NewRequest("PUT", url.String(), bytes.NewBuffer((body)))
-	req.Header.Add("Content-Type", "application/json")
-
-	client := &http.Client{
-		Timeout: time.Second * time.Duration(s.Timeout),
-	}
-	resp, err := client.Do(req)
-	if err != nil {
+func (s *Client) UpdatePerspective(perspectiveID string, perspective *Perspective, opts ...CallOption) (updated *Perspective, err error) {
+	defer annotate(&err, "UpdatePerspective", perspectiveID)
+	if err := perspective.Validate(); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	responseBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	switch resp.StatusCode {
-	case http.StatusOK:
-		var updatedPerspective = new(Perspective)
-		err = json.Unmarshal(responseBody, &updatedPerspective)
-		if err != nil {
-			return nil, err
+	unknownResponse := unknownPerspectiveResponse(perspective)
+	return perspectiveSchemas.update(s, perspectiveID, perspective, func(resp *http.Response) error {
+		if resp.StatusCode == http.StatusUnprocessableEntity {
+			return fmt.Errorf("Bad Request. Please check if a Perspective with this name `%s` already exists", perspective.Schema.Name)
 		}
+		return unknownResponse(resp)
+	}, opts)
+}
 
-		return updatedPerspective, nil
-	case http.StatusUnauthorized:
-		return nil, ErrClientAuthenticationError
-	case http.StatusNotFound:
-		return nil, ErrPerspectiveNotFound
-	case http.StatusUnprocessableEntity:
-		return nil, fmt.Errorf("Bad Request. Please check if a Perspective with this name `%s` already exists", perspective.Schema.Name)
-	default:
-		return nil, fmt.Errorf("Unknown Response with CloudHealth: `%d` when sending:\n%v", resp.StatusCode, string(body))
-	}
+func (s *Client) DeletePerspective(id string, opts ...CallOption) (err error) {
+	defer annotate(&err, "DeletePerspective", id)
+	return s.deletePerspectiveCall(id, "true", opts)
 }
 
-func (s *Client) DeletePerspective(id string) error {
-	return s.deletePerspectiveCall(id, map[string]string{
-		"hard_delete": "true",
-	})
+func (s *Client) ArchivePerspective(id string, opts ...CallOption) (err error) {
+	defer annotate(&err, "ArchivePerspective", id)
+	return s.deletePerspectiveCall(id, "false", opts)
 }
 
-func (s *Client) ArchivePerspective(id string) error {
-	return s.deletePerspectiveCall(id, map[string]string{
-		"hard_delete": "false",
-	})
+func (s *Client) deletePerspectiveCall(id string, hardDelete string, opts []CallOption) error {
+	return perspectiveSchemas.delete(s, id, url.Values{"hard_delete": {hardDelete}}, opts)
 }
 
-func (s *Client) deletePerspectiveCall(id string, opts ...map[string]string) error {
-	relativeURL, _ := url.Parse(fmt.Sprintf("perspective_schemas/%s?api_key=%s", id, s.ApiKey))
-	q := relativeURL.Query()
-	for _, opt := range opts {
-		for k, v := range opt {
-			q.Add(k, v)
-		}
+// unknownPerspectiveResponse reports an unexpected response along with the perspective that was sent.
+func unknownPerspectiveResponse(perspective *Perspective) func(resp *http.Response) error {
+	return func(resp *http.Response) error {
+		body, _ := json.Marshal(perspective)
+		return fmt.Errorf("Unknown Response with CloudHealth when sending:\n%v", string(body))
 	}
+}
 
-	relativeURL.RawQuery = q.Encode()
-
-	url := s.EndpointURL.ResolveReference(relativeURL)
-
-	req, err := http.NewRequest("DELETE", url.String(), nil)
-
-	client := &http.Client{
-		Timeout: time.Second * time.Duration(s.Timeout),
-	}
-	resp, err := client.Do(req)
+// WaitForPerspective polls the Perspective until done reports it in the expected state, returning it. It is
+// meant for changes CloudHealth applies asynchronously, such as large Perspective updates whose rules are still
+// being processed when UpdatePerspective returns.
+func (s *Client) WaitForPerspective(ctx context.Context, id string, poller *Poller, done func(*Perspective) bool, opts ...CallOption) (perspective *Perspective, err error) {
+	defer annotate(&err, "WaitForPerspective", id)
+	opts = append([]CallOption{WithContext(ctx)}, opts...)
+	err = s.withClock(poller).Poll(ctx, func(ctx context.Context) (bool, error) {
+		var err error
+		perspective, err = s.getPerspective(id, opts)
+		if err != nil {
+			return false, err
+		}
+		return done(perspective), nil
+	})
 	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	switch resp.StatusCode {
-	case http.StatusOK:
-		return nil
-	case http.StatusNoContent:
-		return nil
-	case http.StatusNotFound:
-		return ErrPerspectiveNotFound
-	case http.StatusUnauthorized:
-		return ErrClientAuthenticationError
-	default:
-		return fmt.Errorf("Unknown Response with CloudHealth: `%d`", resp.StatusCode)
+		return nil, err
 	}
+	return perspective, nil
 }
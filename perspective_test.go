@@ -2,6 +2,7 @@ package cloudhealth
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -40,7 +41,7 @@ func TestGetPerspectiveOK(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	c, err := NewClient("apiKey", ts.URL)
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
 	if err != nil {
 		t.Errorf("NewClient() returned an error: %s", err)
 		return
@@ -72,13 +73,13 @@ func TestGetPerspectiveEmptyDoesntExist(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	c, err := NewClient("apiKey", ts.URL)
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
 	if err != nil {
 		t.Errorf("NewClient() returned an error: %s", err)
 		return
 	}
 
-	if p, err := c.GetPerspective(defaultPerspectiveID); err != ErrPerspectiveNotFound {
+	if p, err := c.GetPerspective(defaultPerspectiveID); !errors.Is(err, ErrPerspectiveNotFound) {
 		t.Errorf("GetPerspective() returned the wrong error: %s\nGot Perspective:\n%v", err, p)
 		return
 	}
@@ -99,14 +100,14 @@ func TestGetPerspectiveDoesntExist(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	c, err := NewClient("apiKey", ts.URL)
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
 	if err != nil {
 		t.Errorf("NewClient() returned an error: %s", err)
 		return
 	}
 
 	_, err = c.GetPerspective(defaultPerspectiveID)
-	if err != ErrPerspectiveNotFound {
+	if !errors.Is(err, ErrPerspectiveNotFound) {
 		t.Errorf("GetPerspective() returned the wrong error: %s", err)
 		return
 	}
@@ -127,7 +128,7 @@ func TestGetAllPerspectivesOK(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	c, err := NewClient("apiKey", ts.URL)
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
 	if err != nil {
 		t.Errorf("NewClient() returned an error: %s", err)
 		return
@@ -175,7 +176,7 @@ func TestCreatePerspectiveOK(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	c, err := NewClient("apiKey", ts.URL)
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
 	if err != nil {
 		t.Errorf("NewClient() returned an error: %s", err)
 		return
@@ -209,7 +210,7 @@ func TestUpdatePerspectiveOK(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	c, err := NewClient("apiKey", ts.URL)
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
 	if err != nil {
 		t.Errorf("NewClient() returned an error: %s", err)
 		return
@@ -246,7 +247,7 @@ func TestDeletePerspectiveOK(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	c, err := NewClient("apiKey", ts.URL)
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
 	if err != nil {
 		t.Errorf("NewClient() returned an error: %s", err)
 		return
@@ -272,14 +273,14 @@ func TestDeletePerspectiveDoesntExist(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	c, err := NewClient("apiKey", ts.URL)
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
 	if err != nil {
 		t.Errorf("NewClient() returned an error: %s", err)
 		return
 	}
 
 	err = c.DeletePerspective(defaultPerspectiveID)
-	if err != ErrPerspectiveNotFound {
+	if !errors.Is(err, ErrPerspectiveNotFound) {
 		t.Errorf("DeletePerspective() returned the wrong error: %s", err)
 		return
 	}
@@ -301,7 +302,7 @@ func TestArchivePerspectiveOK(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	c, err := NewClient("apiKey", ts.URL)
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
 	if err != nil {
 		t.Errorf("NewClient() returned an error: %s", err)
 		return
@@ -313,3 +314,73 @@ func TestArchivePerspectiveOK(t *testing.T) {
 		return
 	}
 }
+
+func TestGetAllPerspectivesPaged(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("per_page") != "100" {
+			t.Errorf("Expected per_page=100, got ‘%s’", r.URL.RawQuery)
+		}
+		page := PerspectiveMap{}
+		switch r.URL.Query().Get("page") {
+		case "1":
+			for i := 0; i < 100; i++ {
+				page[fmt.Sprint(i)] = PerspectiveStatus{Name: fmt.Sprint("p", i), Active: true}
+			}
+		case "2":
+			page["100"] = PerspectiveStatus{Name: "p100"}
+		default:
+			t.Errorf("Unexpected page ‘%s’", r.URL.Query().Get("page"))
+		}
+		body, _ := json.Marshal(page)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	perspectives, err := c.GetAllPerspectives()
+	if err != nil {
+		t.Errorf("GetAllPerspectives() returned the error: %s", err)
+		return
+	}
+	if len(*perspectives) != 101 || (*perspectives)["100"].Name != "p100" {
+		t.Errorf("GetAllPerspectives() expected 101 Perspectives, got %d", len(*perspectives))
+	}
+}
+
+func TestGetPerspectivesPagesUnpagedListing(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests > 2 {
+			t.Errorf("Expected the listing to stop once pages repeat")
+			return
+		}
+		body, _ := json.Marshal(PerspectiveMap{"1": {Name: "a"}, "2": {Name: "b"}, "3": {Name: "c"}})
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	var pages []PerspectiveMap
+	err = c.GetPerspectivesPages(2, func(page PerspectiveMap) bool {
+		pages = append(pages, page)
+		return true
+	})
+	if err != nil {
+		t.Errorf("GetPerspectivesPages() returned the error: %s", err)
+		return
+	}
+	if len(pages) != 1 || len(pages[0]) != 3 {
+		t.Errorf("GetPerspectivesPages() expected a single page of 3 Perspectives, got %v", pages)
+	}
+}
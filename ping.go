@@ -0,0 +1,75 @@
+package cloudhealth
+
+import (
+	"errors"
+	"net/url"
+	"time"
+)
+
+// PingStatus is the outcome of Ping.
+type PingStatus int
+
+const (
+	// PingOK means CloudHealth accepted the credentials.
+	PingOK PingStatus = iota
+	// PingUnauthorized means CloudHealth rejected the credentials.
+	PingUnauthorized
+	// PingUnreachable means CloudHealth couldn't be reached or didn't answer successfully.
+	PingUnreachable
+)
+
+func (p PingStatus) String() string {
+	switch p {
+	case PingOK:
+		return "ok"
+	case PingUnauthorized:
+		return "unauthorized"
+	default:
+		return "unreachable"
+	}
+}
+
+// Ping makes a minimal authenticated request, listing a single AWS Account, to check that CloudHealth
+// is reachable with the Client's credentials. The error explains any status other than PingOK.
+func (s *Client) Ping(opts ...CallOption) (status PingStatus, err error) {
+	defer annotate(&err, "Ping", "")
+	resp, err := s.send(apiCall{
+		method:  "GET",
+		path:    awsAccounts.path,
+		query:   url.Values{"per_page": {"1"}},
+		options: opts,
+	})
+	switch {
+	case err == nil:
+		return PingOK, resp.Body.Close()
+	case errors.Is(err, ErrClientAuthenticationError):
+		return PingUnauthorized, err
+	default:
+		return PingUnreachable, err
+	}
+}
+
+// Health is the outcome of CheckHealth.
+type Health struct {
+	Status  PingStatus
+	Latency time.Duration // round trip time of the check
+	Err     error         // explains any Status other than PingOK
+}
+
+// Ready reports whether CloudHealth is reachable and accepts the Client's credentials.
+func (h Health) Ready() bool {
+	return h.Status == PingOK
+}
+
+// CheckHealth pings CloudHealth and reports whether it is available, whether the credentials are valid
+// and how long the round trip took, for use as a readiness probe.
+func (s *Client) CheckHealth(opts ...CallOption) Health {
+	clock := s.clock()
+	start := clock.Now()
+	status, err := s.Ping(opts...)
+	return Health{
+		Status:  status,
+		Latency: clock.Now().Sub(start),
+		Err:     err,
+	}
+}
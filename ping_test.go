@@ -0,0 +1,87 @@
+package cloudhealth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPing(t *testing.T) {
+	tests := []struct {
+		status   int
+		expected PingStatus
+	}{
+		{http.StatusOK, PingOK},
+		{http.StatusUnauthorized, PingUnauthorized},
+		{http.StatusForbidden, PingUnauthorized},
+		{http.StatusBadGateway, PingUnreachable},
+	}
+	for _, test := range tests {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.EscapedPath() != "/aws_accounts" || r.URL.Query().Get("per_page") != "1" {
+				t.Errorf("Expected request to ‘/aws_accounts?per_page=1’, got ‘%s’", r.URL)
+			}
+			w.WriteHeader(test.status)
+		}))
+
+		c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+		if err != nil {
+			t.Errorf("NewClient() returned an error: %s", err)
+			ts.Close()
+			return
+		}
+		status, err := c.Ping()
+		if status != test.expected {
+			t.Errorf("Ping() for status %d expected %s, got %s (%v)", test.status, test.expected, status, err)
+		}
+		if (status == PingOK) != (err == nil) {
+			t.Errorf("Ping() for status %d returned status %s with error %v", test.status, status, err)
+		}
+		ts.Close()
+	}
+}
+
+func TestPingUnreachable(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	status, err := c.Ping()
+	if status != PingUnreachable || err == nil || errors.Is(err, ErrClientAuthenticationError) {
+		t.Errorf("Ping() expected unreachable, got %s (%v)", status, err)
+	}
+}
+
+func TestCheckHealth(t *testing.T) {
+	clock := newFakeClock()
+	status := http.StatusOK
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clock.Advance(120 * time.Millisecond)
+		w.WriteHeader(status)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.Clock = clock
+
+	health := c.CheckHealth()
+	if !health.Ready() || health.Err != nil || health.Latency != 120*time.Millisecond {
+		t.Errorf("CheckHealth() expected a ready result with 120ms latency, got %+v", health)
+	}
+
+	status = http.StatusUnauthorized
+	health = c.CheckHealth()
+	if health.Ready() || health.Status != PingUnauthorized || health.Err == nil {
+		t.Errorf("CheckHealth() expected an unauthorized result, got %+v", health)
+	}
+}
@@ -0,0 +1,183 @@
+package cloudhealth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrStalePlan is returned when applying a PerspectivePlan whose Perspective changed since it was planned.
+var ErrStalePlan = errors.New("Perspective changed since the plan was made")
+
+// Actions of a PerspectiveChange.
+const (
+	ChangeAdd    = "+"
+	ChangeRemove = "-"
+	ChangeUpdate = "~"
+)
+
+// PerspectiveChange is a single difference between the live and desired Perspective.
+type PerspectiveChange struct {
+	Path   string      // e.g. "$.schema.rules[1].to"
+	Action string      // ChangeAdd, ChangeRemove or ChangeUpdate
+	Before interface{} // decoded JSON value, nil when added
+	After  interface{} // decoded JSON value, nil when removed
+}
+
+func (c PerspectiveChange) String() string {
+	before, _ := json.Marshal(c.Before)
+	after, _ := json.Marshal(c.After)
+	switch c.Action {
+	case ChangeAdd:
+		return fmt.Sprintf("%s %s: %s", c.Action, c.Path, after)
+	case ChangeRemove:
+		return fmt.Sprintf("%s %s: %s", c.Action, c.Path, before)
+	default:
+		return fmt.Sprintf("%s %s: %s => %s", c.Action, c.Path, before, after)
+	}
+}
+
+// PerspectivePlan is the change set of making a Perspective match the desired schema, for review before ApplyPerspectivePlan.
+type PerspectivePlan struct {
+	ID      string       // empty when the Perspective will be created
+	Current *Perspective // nil when the Perspective will be created
+	Desired *Perspective
+	Changes []PerspectiveChange
+}
+
+// String returns the plan as a human-readable change set.
+func (p *PerspectivePlan) String() string {
+	var b strings.Builder
+	switch {
+	case p.Current == nil:
+		fmt.Fprintf(&b, "Perspective %q will be created", p.Desired.Schema.Name)
+	case len(p.Changes) == 0:
+		fmt.Fprintf(&b, "Perspective %q (%s) is up to date", p.Desired.Schema.Name, p.ID)
+	default:
+		fmt.Fprintf(&b, "Perspective %q (%s) will be updated", p.Desired.Schema.Name, p.ID)
+	}
+	for _, change := range p.Changes {
+		b.WriteString("\n  ")
+		b.WriteString(change.String())
+	}
+	return b.String()
+}
+
+// PlanPerspective compares the Perspective with the given ID, or a new Perspective when id is empty,
+// with the desired one without changing anything.
+func (s *Client) PlanPerspective(id string, desired *Perspective, opts ...CallOption) (plan *PerspectivePlan, err error) {
+	defer annotate(&err, "PlanPerspective", id)
+	plan = &PerspectivePlan{ID: id, Desired: desired.DeepCopy()}
+	if id != "" {
+		if plan.Current, err = s.GetPerspective(id, opts...); err != nil {
+			return nil, err
+		}
+	}
+	if plan.Changes, err = diffPerspectives(plan.Current, plan.Desired); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// ApplyPerspectivePlan makes the changes of the plan, returning the ID of the Perspective. It fails with ErrStalePlan
+// when the Perspective changed since the plan was made, and makes no request when there are no changes.
+func (s *Client) ApplyPerspectivePlan(plan *PerspectivePlan, opts ...CallOption) (id string, err error) {
+	defer annotate(&err, "ApplyPerspectivePlan", plan.ID)
+	if plan.Current == nil {
+		return s.CreatePerspective(plan.Desired, opts...)
+	}
+	if len(plan.Changes) == 0 {
+		return plan.ID, nil
+	}
+	live, err := s.GetPerspective(plan.ID, opts...)
+	if err != nil {
+		return "", err
+	}
+	if !reflect.DeepEqual(live, plan.Current) {
+		return "", ErrStalePlan
+	}
+	if _, err := s.UpdatePerspective(plan.ID, plan.Desired, opts...); err != nil {
+		return "", err
+	}
+	return plan.ID, nil
+}
+
+// diffPerspectives lists the differences between the JSON encodings of the Perspectives.
+func diffPerspectives(current, desired *Perspective) ([]PerspectiveChange, error) {
+	var before, after interface{}
+	if current != nil {
+		if err := roundTripJSON(current, &before); err != nil {
+			return nil, err
+		}
+	}
+	if err := roundTripJSON(desired, &after); err != nil {
+		return nil, err
+	}
+	var changes []PerspectiveChange
+	diffJSON("$", before, after, &changes)
+	return changes, nil
+}
+
+func roundTripJSON(in interface{}, out *interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// diffJSON appends the differences between the decoded JSON values a and b at path to changes.
+func diffJSON(path string, a, b interface{}, changes *[]PerspectiveChange) {
+	switch {
+	case reflect.DeepEqual(a, b):
+		return
+	case a == nil:
+		*changes = append(*changes, PerspectiveChange{Path: path, Action: ChangeAdd, After: b})
+		return
+	case b == nil:
+		*changes = append(*changes, PerspectiveChange{Path: path, Action: ChangeRemove, Before: a})
+		return
+	}
+
+	aMap, aIsMap := a.(map[string]interface{})
+	bMap, bIsMap := b.(map[string]interface{})
+	if aIsMap && bIsMap {
+		keys := map[string]bool{}
+		for k := range aMap {
+			keys[k] = true
+		}
+		for k := range bMap {
+			keys[k] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+		for _, k := range sorted {
+			diffJSON(path+"."+k, aMap[k], bMap[k], changes)
+		}
+		return
+	}
+
+	aList, aIsList := a.([]interface{})
+	bList, bIsList := b.([]interface{})
+	if aIsList && bIsList {
+		for i := 0; i < len(aList) || i < len(bList); i++ {
+			var ai, bi interface{}
+			if i < len(aList) {
+				ai = aList[i]
+			}
+			if i < len(bList) {
+				bi = bList[i]
+			}
+			diffJSON(fmt.Sprintf("%s[%d]", path, i), ai, bi, changes)
+		}
+		return
+	}
+
+	*changes = append(*changes, PerspectiveChange{Path: path, Action: ChangeUpdate, Before: a, After: b})
+}
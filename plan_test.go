@@ -0,0 +1,136 @@
+package cloudhealth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestPlanPerspective(t *testing.T) {
+	live := Perspective{Schema: Schema{
+		Name:             "Environment",
+		IncludeInReports: "true",
+		Rules:            []Rule{{Type: "filter", Asset: "AwsInstance", To: "1"}},
+		Constants:        []Constant{{Type: StaticGroupType, List: []ConstantItem{{RefID: "1", Name: "prod"}}}},
+	}}
+	var updates int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			json.NewEncoder(w).Encode(live)
+		case "PUT":
+			updates++
+			json.NewDecoder(r.Body).Decode(&live)
+			json.NewEncoder(w).Encode(live)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	desired := live.DeepCopy()
+	desired.Schema.Rules[0].To = "2"
+	desired.Schema.Rules = append(desired.Schema.Rules, Rule{Type: "filter", Asset: "AwsRdsInstance", To: "1"})
+	desired.Schema.Constants[0].List = append(desired.Schema.Constants[0].List, ConstantItem{RefID: "2", Name: "dev"})
+
+	plan, err := c.PlanPerspective("10", desired)
+	if err != nil {
+		t.Errorf("PlanPerspective() returned an error: %s", err)
+		return
+	}
+	expected := `Perspective "Environment" (10) will be updated
+  + $.schema.constants[0].list[1]: {"name":"dev","ref_id":"2"}
+  ~ $.schema.rules[0].to: "1" => "2"
+  + $.schema.rules[1]: {"asset":"AwsRdsInstance","to":"1","type":"filter"}`
+	if plan.String() != expected {
+		t.Errorf("PlanPerspective() expected the change set\n%s\ngot\n%s", expected, plan)
+	}
+	if updates != 0 {
+		t.Errorf("PlanPerspective() changed the Perspective")
+	}
+
+	if _, err := c.ApplyPerspectivePlan(plan); err != nil {
+		t.Errorf("ApplyPerspectivePlan() returned an error: %s", err)
+		return
+	}
+	if updates != 1 || live.Schema.Rules[0].To != "2" {
+		t.Errorf("ApplyPerspectivePlan() expected the Perspective to be updated, got %d updates", updates)
+	}
+
+	if _, err := c.ApplyPerspectivePlan(plan); !errors.Is(err, ErrStalePlan) {
+		t.Errorf("ApplyPerspectivePlan() expected ErrStalePlan when applied twice, got %v", err)
+	}
+}
+
+func TestPlanPerspectiveCreate(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("Perspective 30 created"))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	plan, err := c.PlanPerspective("", &defaultPerspective)
+	if err != nil {
+		t.Errorf("PlanPerspective() returned an error: %s", err)
+		return
+	}
+	if plan.Current != nil || len(plan.Changes) != 1 || plan.Changes[0].Path != "$" || plan.Changes[0].Action != ChangeAdd {
+		t.Errorf("PlanPerspective() expected a creation, got\n%s", plan)
+	}
+	if id, err := c.ApplyPerspectivePlan(plan); err != nil || id != "30" {
+		t.Errorf("ApplyPerspectivePlan() expected Perspective 30, got ‘%s’, %v", id, err)
+	}
+}
+
+func TestApplyPerspectivePlanOptions(t *testing.T) {
+	live := Perspective{Schema: Schema{Name: "Environment", IncludeInReports: "true"}}
+	var auths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auths = append(auths, r.Method+" "+r.Header.Get("Authorization"))
+		json.NewEncoder(w).Encode(live)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	desired := live.DeepCopy()
+	desired.Schema.IncludeInReports = "false"
+	plan, err := c.PlanPerspective("10", desired, WithApiKey("tenantKey"))
+	if err != nil {
+		t.Errorf("PlanPerspective() returned an error: %s", err)
+		return
+	}
+	if _, err := c.ApplyPerspectivePlan(plan, WithApiKey("tenantKey")); err != nil {
+		t.Errorf("ApplyPerspectivePlan() returned an error: %s", err)
+	}
+	expected := []string{"GET Bearer tenantKey", "GET Bearer tenantKey", "PUT Bearer tenantKey"}
+	if !reflect.DeepEqual(auths, expected) {
+		t.Errorf("Expected requests %v, got %v", expected, auths)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.ApplyPerspectivePlan(plan, WithContext(ctx)); !errors.Is(err, context.Canceled) {
+		t.Errorf("ApplyPerspectivePlan() expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package cloudhealth
+
+import (
+	"context"
+	"time"
+)
+
+// Poller repeatedly checks an asynchronous operation, such as CloudHealth processing an AWS Account or applying a
+// Perspective update, until it reaches a terminal state. The wait between checks starts at Interval and is
+// multiplied by Backoff after every check, up to MaxInterval.
+type Poller struct {
+	Interval    time.Duration
+	Backoff     float64       // factor growing the wait after every check; values up to 1 keep it constant
+	MaxInterval time.Duration // upper bound of the wait; zero for none
+	Clock       Clock         // defaults to the real time
+}
+
+// NewPoller returns a Poller checking every interval.
+func NewPoller(interval time.Duration) *Poller {
+	return &Poller{Interval: interval}
+}
+
+// Poll calls check until it reports done or returns an error, which Poll then returns. When ctx is done while
+// waiting between checks, its error is returned.
+func (p *Poller) Poll(ctx context.Context, check func(ctx context.Context) (done bool, err error)) error {
+	clock := p.Clock
+	if clock == nil {
+		clock = realClock{}
+	}
+	wait := p.Interval
+	for {
+		done, err := check(ctx)
+		if err != nil || done {
+			return err
+		}
+		if err := clock.Sleep(ctx, wait); err != nil {
+			return err
+		}
+		if p.Backoff > 1 {
+			wait = time.Duration(float64(wait) * p.Backoff)
+		}
+		if p.MaxInterval > 0 && wait > p.MaxInterval {
+			wait = p.MaxInterval
+		}
+	}
+}
+
+// withClock returns p, or a copy of it using the Client's Clock when p has none.
+func (s *Client) withClock(p *Poller) *Poller {
+	if p.Clock != nil {
+		return p
+	}
+	withClock := *p
+	withClock.Clock = s.clock()
+	return &withClock
+}
@@ -0,0 +1,95 @@
+package cloudhealth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPollerBackoff(t *testing.T) {
+	clock := newFakeClock()
+	p := &Poller{Interval: time.Second, Backoff: 2, MaxInterval: 5 * time.Second, Clock: clock}
+	checks := 0
+	err := p.Poll(context.Background(), func(ctx context.Context) (bool, error) {
+		checks++
+		return checks == 5, nil
+	})
+	if err != nil {
+		t.Errorf("Poll() returned an error: %s", err)
+		return
+	}
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}
+	if !reflect.DeepEqual(clock.sleeps, expected) {
+		t.Errorf("Poll() expected waits %v, got %v", expected, clock.sleeps)
+	}
+}
+
+func TestPollerCheckError(t *testing.T) {
+	checkErr := errors.New("failed")
+	p := &Poller{Interval: time.Second, Clock: newFakeClock()}
+	err := p.Poll(context.Background(), func(ctx context.Context) (bool, error) {
+		return false, checkErr
+	})
+	if err != checkErr {
+		t.Errorf("Poll() expected the check error, got %v", err)
+	}
+}
+
+func TestPollerCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Poller{Interval: time.Second, Clock: newFakeClock()}
+	checks := 0
+	err := p.Poll(ctx, func(ctx context.Context) (bool, error) {
+		checks++
+		if checks == 3 {
+			cancel()
+		}
+		return false, nil
+	})
+	if err != context.Canceled || checks != 3 {
+		t.Errorf("Poll() expected to stop after 3 checks with context.Canceled, got %v after %d checks", err, checks)
+	}
+}
+
+func TestWaitForPerspective(t *testing.T) {
+	gets := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		expectedURL := fmt.Sprintf("/perspective_schemas/%s", defaultPerspectiveID)
+		if r.URL.EscapedPath() != expectedURL {
+			t.Errorf("Expected request to ‘%s’, got ‘%s’", expectedURL, r.URL.EscapedPath())
+		}
+		perspective := defaultPerspective
+		if gets == 2 {
+			perspective.Schema.IncludeInReports = "false"
+		}
+		body, _ := json.Marshal(perspective)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	clock := newFakeClock()
+	c.Clock = clock
+
+	perspective, err := c.WaitForPerspective(context.Background(), defaultPerspectiveID, NewPoller(time.Minute), func(p *Perspective) bool {
+		return p.Schema.IncludeInReports == "false"
+	})
+	if err != nil {
+		t.Errorf("WaitForPerspective() returned an error: %s", err)
+		return
+	}
+	if perspective.Schema.IncludeInReports != "false" || len(clock.sleeps) != 1 {
+		t.Errorf("WaitForPerspective() expected the updated Perspective after one wait, got %v after %v", perspective.Schema, clock.sleeps)
+	}
+}
@@ -0,0 +1,64 @@
+package cloudhealth
+
+import (
+	"net/http"
+	"sync"
+)
+
+// ClientPool caches a configured Client per API key, for services talking to many CloudHealth tenants.
+// Clients of a pool share a single HTTP transport and its connections.
+type ClientPool struct {
+	endpointURL string
+	configure   func(*Client)
+
+	mu        sync.Mutex
+	clients   map[string]*Client
+	transport http.RoundTripper
+}
+
+// NewClientPool returns a ClientPool creating Clients for endpointURL. configure, when not nil, is applied
+// to every new Client before its first use, e.g. to set a shared CircuitBreaker or StatsHook.
+func NewClientPool(endpointURL string, configure func(*Client)) *ClientPool {
+	return &ClientPool{
+		endpointURL: endpointURL,
+		configure:   configure,
+		clients:     make(map[string]*Client),
+	}
+}
+
+// Get returns the Client for apiKey, creating it on first use.
+func (p *ClientPool) Get(apiKey string) (*Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if c, ok := p.clients[apiKey]; ok {
+		return c, nil
+	}
+	c, err := NewClient(apiKey, WithEndpoint(p.endpointURL))
+	if err != nil {
+		return nil, err
+	}
+	if p.configure != nil {
+		p.configure(c)
+	}
+	if p.transport == nil {
+		p.transport = c.newHTTPTransport()
+	}
+	c.baseTransport = p.transport
+	p.clients[apiKey] = c
+	return c, nil
+}
+
+// Remove evicts the Client for apiKey, e.g. when a tenant is offboarded or its key is revoked.
+func (p *ClientPool) Remove(apiKey string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.clients, apiKey)
+}
+
+// Len returns the number of cached Clients.
+func (p *ClientPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.clients)
+}
@@ -0,0 +1,93 @@
+package cloudhealth
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClientPool(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"generated_external_id":"externalid"}`))
+	}))
+	defer ts.Close()
+
+	configured := 0
+	pool := NewClientPool(ts.URL, func(c *Client) {
+		configured++
+		c.Timeout = 5 * time.Second
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c, err := pool.Get(fmt.Sprintf("tenant%d", i%4))
+			if err != nil {
+				t.Errorf("Get() returned an error: %s", err)
+				return
+			}
+			if _, err := c.GetAwsExternalID(); err != nil {
+				t.Errorf("GetAwsExternalID() returned an error: %s", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if pool.Len() != 4 || configured != 4 {
+		t.Errorf("Expected 4 configured Clients, got %d Clients configured %d times", pool.Len(), configured)
+	}
+
+	a, _ := pool.Get("tenant0")
+	b, _ := pool.Get("tenant1")
+	if a.Timeout != 5*time.Second {
+		t.Errorf("Expected Clients to be configured, got Timeout %s", a.Timeout)
+	}
+	if a.ApiKey != "tenant0" || b.ApiKey != "tenant1" {
+		t.Errorf("Expected Clients for their own API key, got ‘%s’ and ‘%s’", a.ApiKey, b.ApiKey)
+	}
+	if a.baseTransport == nil || a.baseTransport != b.baseTransport {
+		t.Errorf("Expected Clients to share their transport")
+	}
+
+	pool.Remove("tenant0")
+	if c, _ := pool.Get("tenant0"); c == a {
+		t.Errorf("Expected a new Client after Remove()")
+	}
+}
+
+func TestClientPoolSharedCircuitBreaker(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"generated_external_id":"externalid"}`))
+	}))
+	defer ts.Close()
+
+	breaker := NewCircuitBreaker(5, time.Minute)
+	pool := NewClientPool(ts.URL, func(c *Client) {
+		c.CircuitBreaker = breaker
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c, err := pool.Get(fmt.Sprintf("tenant%d", i))
+			if err != nil {
+				t.Errorf("Get() returned an error: %s", err)
+				return
+			}
+			if _, err := c.GetAwsExternalID(); err != nil {
+				t.Errorf("GetAwsExternalID() returned an error: %s", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if breaker.Clock != nil {
+		t.Errorf("Expected the pooled Clients to leave the shared CircuitBreaker's Clock unset")
+	}
+}
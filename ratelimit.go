@@ -0,0 +1,107 @@
+package cloudhealth
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimit is the API quota reported by CloudHealth in the X-RateLimit-* response headers.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time // zero when not reported
+}
+
+// RateLimit returns the quota reported by the most recent response carrying rate limit headers, or nil if none did.
+func (s *Client) RateLimit() *RateLimit {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.rateLimit
+}
+
+// parseRateLimit reads the X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset headers,
+// returning nil when the response doesn't report a quota. The reset is accepted either as a Unix
+// timestamp or as a number of seconds from now.
+func parseRateLimit(header http.Header, now time.Time) *RateLimit {
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return nil
+	}
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return nil
+	}
+	rl := &RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+	}
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		if reset > 1000000000 {
+			rl.Reset = time.Unix(reset, 0)
+		} else {
+			rl.Reset = now.Add(time.Duration(reset) * time.Second)
+		}
+	}
+	return rl
+}
+
+// ErrRateLimited is wrapped by the APIError of a request CloudHealth kept throttling with 429 Too Many Requests
+// after the Client waited its RateLimitBudget.
+var ErrRateLimited = errors.New("Rate limited by CloudHealth")
+
+// DefaultRateLimitBudget is the RateLimitBudget of Clients that don't set one.
+const DefaultRateLimitBudget = time.Minute
+
+// rateLimitBudget returns the time a call may wait for throttling to end, zero when waiting is disabled.
+func (s *Client) rateLimitBudget() time.Duration {
+	switch {
+	case s.RateLimitBudget < 0:
+		return 0
+	case s.RateLimitBudget == 0:
+		return DefaultRateLimitBudget
+	}
+	return s.RateLimitBudget
+}
+
+// throttle makes the Client's calls hold off until until, when CloudHealth throttled one of them.
+func (s *Client) throttle(until time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if until.After(s.throttled) {
+		s.throttled = until
+	}
+}
+
+// throttledFor returns how long calls still hold off after CloudHealth throttled one of them.
+func (s *Client) throttledFor() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return nonNegative(s.throttled.Sub(s.clock().Now()))
+}
+
+// throttleWait returns how long to wait before retrying a request throttled with resp: the Retry-After header,
+// given in seconds or as a date, or else the reset of an exhausted X-RateLimit quota. ok is false when the
+// response doesn't tell.
+func throttleWait(header http.Header, now time.Time) (wait time.Duration, ok bool) {
+	if retryAfter := header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			return nonNegative(date.Sub(now)), true
+		}
+	}
+	if rl := parseRateLimit(header, now); rl != nil && rl.Remaining == 0 && !rl.Reset.IsZero() {
+		return nonNegative(rl.Reset.Sub(now)), true
+	}
+	return 0, false
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
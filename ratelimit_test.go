@@ -0,0 +1,166 @@
+package cloudhealth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	now := time.Unix(1586736000, 0)
+
+	header := http.Header{}
+	if rl := parseRateLimit(header, now); rl != nil {
+		t.Errorf("parseRateLimit() expected nil without headers, got %+v", rl)
+	}
+
+	header.Set("X-RateLimit-Limit", "100")
+	header.Set("X-RateLimit-Remaining", "42")
+	header.Set("X-RateLimit-Reset", "30")
+	rl := parseRateLimit(header, now)
+	if rl == nil || rl.Limit != 100 || rl.Remaining != 42 || !rl.Reset.Equal(now.Add(30*time.Second)) {
+		t.Errorf("parseRateLimit() returned %+v", rl)
+	}
+
+	header.Set("X-RateLimit-Reset", "1586736060")
+	rl = parseRateLimit(header, now)
+	if rl == nil || !rl.Reset.Equal(time.Unix(1586736060, 0)) {
+		t.Errorf("parseRateLimit() returned %+v", rl)
+	}
+}
+
+func TestClientRateLimit(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	var stats RequestStats
+	c.StatsHook = func(s RequestStats) { stats = s }
+
+	if err := c.DeleteAwsAccount(defaultAWSAccount.ID); err != nil {
+		t.Errorf("DeleteAwsAccount() returned an error: %s", err)
+		return
+	}
+	if rl := c.RateLimit(); rl == nil || rl.Limit != 100 || rl.Remaining != 99 {
+		t.Errorf("Unexpected RateLimit(): %+v", rl)
+	}
+	if stats.RateLimit == nil || stats.RateLimit.Remaining != 99 {
+		t.Errorf("Unexpected RequestStats.RateLimit: %+v", stats.RateLimit)
+	}
+}
+
+func TestThrottleWait(t *testing.T) {
+	now := time.Unix(1586736000, 0)
+	tests := []struct {
+		header   http.Header
+		expected time.Duration
+		ok       bool
+	}{
+		{http.Header{"Retry-After": {"30"}}, 30 * time.Second, true},
+		{http.Header{"Retry-After": {now.Add(time.Minute).UTC().Format(http.TimeFormat)}}, time.Minute, true},
+		{http.Header{"X-Ratelimit-Limit": {"100"}, "X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"20"}}, 20 * time.Second, true},
+		{http.Header{"X-Ratelimit-Limit": {"100"}, "X-Ratelimit-Remaining": {"5"}, "X-Ratelimit-Reset": {"20"}}, 0, false},
+		{http.Header{}, 0, false},
+	}
+	for _, test := range tests {
+		if wait, ok := throttleWait(test.header, now); wait != test.expected || ok != test.ok {
+			t.Errorf("throttleWait(%v) expected %s, %t, got %s, %t", test.header, test.expected, test.ok, wait, ok)
+		}
+	}
+}
+
+func TestRateLimitedRetry(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "10")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"id":1,"name":"test"}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	clock := newFakeClock()
+	c.Clock = clock
+
+	if _, err := c.GetAwsAccount(1); err != nil {
+		t.Errorf("GetAwsAccount() returned an error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(clock.sleeps, []time.Duration{10 * time.Second}) {
+		t.Errorf("Expected to wait the Retry-After of 10s, got %v", clock.sleeps)
+	}
+}
+
+func TestRateLimitedBudgetExhausted(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "20")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.Clock = newFakeClock()
+	c.RateLimitBudget = 45 * time.Second
+
+	_, err = c.GetAwsAccount(1)
+	var apiErr *APIError
+	if !errors.Is(err, ErrRateLimited) || !errors.As(err, &apiErr) || attempts != 3 {
+		t.Errorf("GetAwsAccount() expected ErrRateLimited after 3 attempts, got %v after %d", err, attempts)
+	}
+
+	attempts = 0
+	c.RateLimitBudget = -1
+	if _, err := c.GetAwsAccount(1); !errors.Is(err, ErrRateLimited) || attempts != 1 {
+		t.Errorf("GetAwsAccount() expected ErrRateLimited without retrying, got %v after %d attempts", err, attempts)
+	}
+}
+
+func TestRateLimitedSharedWait(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":1,"name":"test"}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	clock := newFakeClock()
+	c.Clock = clock
+
+	// Another call of the Client was throttled for 30s, 10s ago.
+	c.throttle(clock.Now().Add(20 * time.Second))
+	if _, err := c.GetAwsAccount(1); err != nil {
+		t.Errorf("GetAwsAccount() returned an error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(clock.sleeps, []time.Duration{20 * time.Second}) {
+		t.Errorf("Expected to hold off for the remaining 20s, got %v", clock.sleeps)
+	}
+}
@@ -0,0 +1,32 @@
+package cloudhealth
+
+import "fmt"
+
+// Region is a CloudHealth deployment, selecting the endpoint of the API with WithRegion.
+type Region string
+
+// CloudHealth deployments.
+const (
+	RegionUS Region = "us" // reached at DefaultEndpointURL
+	RegionEU Region = "eu" // reached at EUEndpointURL
+)
+
+// EUEndpointURL is the URL of the API of the EU deployment of CloudHealth.
+const EUEndpointURL = "https://chapi.eu.cloudhealthtech.com/v1/"
+
+// regionEndpoints maps each Region to the URL of its API.
+var regionEndpoints = map[Region]string{
+	RegionUS: DefaultEndpointURL,
+	RegionEU: EUEndpointURL,
+}
+
+// WithRegion sets the URL of the CloudHealth API to the endpoint of region.
+func WithRegion(region Region) ClientOption {
+	return func(s *Client) error {
+		endpointURL, ok := regionEndpoints[region]
+		if !ok {
+			return fmt.Errorf("Unknown CloudHealth region `%s`", region)
+		}
+		return WithEndpoint(endpointURL)(s)
+	}
+}
@@ -0,0 +1,22 @@
+package cloudhealth
+
+import "testing"
+
+func TestWithRegion(t *testing.T) {
+	for region, endpoint := range map[Region]string{RegionUS: DefaultEndpointURL, RegionEU: EUEndpointURL} {
+		c, err := NewClient("apiKey", WithRegion(region))
+		if err != nil {
+			t.Errorf("NewClient() returned an error: %s", err)
+			return
+		}
+		if c.EndpointURL.String() != endpoint {
+			t.Errorf("WithRegion(%s) expected endpoint %s, got %s", region, endpoint, c.EndpointURL)
+		}
+	}
+}
+
+func TestWithRegionUnknown(t *testing.T) {
+	if _, err := NewClient("apiKey", WithRegion("mars")); err == nil {
+		t.Errorf("NewClient() expected an error for an unknown region")
+	}
+}
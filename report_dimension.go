@@ -0,0 +1,31 @@
+package cloudhealth
+
+import "strings"
+
+// ReportDimension identifies a dimension of CloudHealth OLAP reports, passed in the dimensions[] and filters[]
+// query parameters of report requests such as those made with Download.
+type ReportDimension string
+
+// Common report dimensions.
+const (
+	DimensionTime               ReportDimension = "time"
+	DimensionAwsAccount         ReportDimension = "AWS-Account"
+	DimensionAwsServiceCategory ReportDimension = "AWS-Service-Category"
+)
+
+// PerspectiveDimension returns the dimension grouping a report by the groups of the Perspective with the given ID.
+func PerspectiveDimension(perspectiveID string) ReportDimension {
+	return ReportDimension(perspectiveID)
+}
+
+// TagDimension returns the dimension grouping a report by the values of the AWS tag key, which has to be enabled
+// for reporting in CloudHealth.
+func TagDimension(key string) ReportDimension {
+	return ReportDimension("AWS-Tag-" + key)
+}
+
+// Select returns the filter restricting a report to the given members of the dimension, such as Perspective
+// group IDs or "-1" for the current month of DimensionTime.
+func (d ReportDimension) Select(members ...string) string {
+	return string(d) + ":select:" + strings.Join(members, ",")
+}
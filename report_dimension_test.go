@@ -0,0 +1,18 @@
+package cloudhealth
+
+import "testing"
+
+func TestReportDimensionSelect(t *testing.T) {
+	if filter := DimensionTime.Select("-1"); filter != "time:select:-1" {
+		t.Errorf("Select() returned an unexpected filter: %s", filter)
+	}
+	if filter := PerspectiveDimension(defaultPerspectiveID).Select("1", "2"); filter != defaultPerspectiveID+":select:1,2" {
+		t.Errorf("Select() returned an unexpected filter: %s", filter)
+	}
+}
+
+func TestTagDimension(t *testing.T) {
+	if dim := TagDimension("team"); dim != "AWS-Tag-team" {
+		t.Errorf("TagDimension() returned an unexpected dimension: %s", dim)
+	}
+}
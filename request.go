@@ -0,0 +1,194 @@
+package cloudhealth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// apiCall describes a request to the CloudHealth API and how its response statuses map to results.
+type apiCall struct {
+	method string
+	path   string // relative to the Client's EndpointURL and BasePath
+	query  url.Values
+	body   interface{} // encoded as the JSON request body when non-nil
+	// schema names the embedded JSON Schema the body is checked against when the Client validates payloads.
+	schema string
+	// maxBody, when set, is the size in bytes above which the encoded body is rejected before sending.
+	maxBody int64
+
+	// ok lists the statuses of a successful response, defaulting to 200 OK.
+	ok []int
+	// notFound is returned on a 404 Not Found, which is otherwise an unknown response.
+	notFound error
+	// errorFor maps the statuses specific to an endpoint (e.g. 422 Unprocessable Entity) to an error.
+	// Returning nil falls back to the generic unknown response error.
+	errorFor func(resp *http.Response) error
+
+	// options are the CallOptions given by the caller of the public Client method.
+	options []CallOption
+	// apiKey overrides the Client's API key for this call when not empty.
+	apiKey string
+	// ctx of the request, defaulting to context.Background().
+	ctx context.Context
+	// startPage is the first page retrieved by listings, defaulting to 1.
+	startPage int
+	// idempotencyKey identifies repeated attempts of a create.
+	idempotencyKey string
+	// timeout overrides the Client's Timeout for each request of this call when positive.
+	timeout time.Duration
+}
+
+// callOptions returns the settings made by opts, for calls acting on them before any request is sent.
+func callOptions(opts []CallOption) apiCall {
+	var c apiCall
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.ctx == nil {
+		c.ctx = context.Background()
+	}
+	return c
+}
+
+// url returns the absolute URL of the call.
+func (s *Client) url(c apiCall) *url.URL {
+	return s.baseURL().ResolveReference(&url.URL{Path: strings.TrimPrefix(c.path, "/"), RawQuery: c.query.Encode()})
+}
+
+// send makes the call, returning the response with its body still to be read when the status is successful.
+// Any other status is mapped to an error and the response body is closed.
+func (s *Client) send(c apiCall) (*http.Response, error) {
+	if c.schema != "" && s.ValidatePayloads {
+		if err := validatePayload(c.schema, c.body); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, opt := range c.options {
+		opt(&c)
+	}
+
+	apiKey := c.apiKey
+	var err error
+	if apiKey == "" {
+		if apiKey, err = s.apiKey(); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	hc := s.httpClient()
+	if c.timeout > 0 {
+		override := *hc
+		override.Timeout = c.timeout
+		hc = &override
+	}
+	resp, err := s.doWithRetries(ctx, hc, func(ctx context.Context) (*http.Request, error) {
+		return s.newRequest(ctx, c, apiKey)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ok := c.ok
+	if len(ok) == 0 {
+		ok = []int{http.StatusOK}
+	}
+	for _, status := range ok {
+		if resp.StatusCode == status {
+			return resp, nil
+		}
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, newAPIError(resp, ErrClientAuthenticationError)
+	case http.StatusTooManyRequests:
+		return nil, newAPIError(resp, ErrRateLimited)
+	case http.StatusNotFound:
+		if c.notFound != nil {
+			return nil, newAPIError(resp, c.notFound)
+		}
+	}
+	if c.errorFor != nil {
+		if err := c.errorFor(resp); err != nil {
+			return nil, newAPIError(resp, err)
+		}
+	}
+	return nil, newAPIError(resp, nil)
+}
+
+// newRequest builds the HTTP request of the call, authenticated with apiKey.
+func (s *Client) newRequest(ctx context.Context, c apiCall, apiKey string) (*http.Request, error) {
+	var req *http.Request
+	var err error
+	if c.body != nil {
+		req, err = newJSONRequest(ctx, c.method, s.url(c).String(), c.body)
+	} else {
+		req, err = http.NewRequestWithContext(ctx, c.method, s.url(c).String(), nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if c.maxBody > 0 && req.ContentLength > c.maxBody {
+		finish := finishRequest(req)
+		req.Body.Close()
+		finish()
+		return nil, &PayloadTooLargeError{Size: req.ContentLength, Limit: c.maxBody}
+	}
+	s.authenticate(req, apiKey)
+	req.Header.Set("User-Agent", s.userAgent())
+	return req, nil
+}
+
+// userAgent returns the User-Agent header of the Client's requests.
+func (s *Client) userAgent() string {
+	if s.UserAgent == "" {
+		return defaultUserAgent
+	}
+	return defaultUserAgent + " " + s.UserAgent
+}
+
+// do makes the call and decodes the JSON response into a new T.
+func do[T any](s *Client, c apiCall) (*T, error) {
+	resp, err := s.send(c)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result = new(T)
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// get retrieves the resource at path, returning notFound when it doesn't exist.
+func get[T any](s *Client, path string, notFound error, opts ...CallOption) (*T, error) {
+	return do[T](s, apiCall{method: "GET", path: path, notFound: notFound, options: opts})
+}
+
+// del deletes the resource at path, returning notFound when it doesn't exist.
+func (s *Client) del(path string, query url.Values, notFound error, opts ...CallOption) error {
+	resp, err := s.send(apiCall{
+		method:   "DELETE",
+		path:     path,
+		query:    query,
+		ok:       []int{http.StatusOK, http.StatusNoContent},
+		notFound: notFound,
+		options:  opts,
+	})
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
@@ -0,0 +1,48 @@
+package cloudhealth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// RequestIDHeader is the header carrying the correlation ID of a request to CloudHealth.
+const RequestIDHeader = "X-Request-Id"
+
+// newRequestID returns a random correlation ID.
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// requestIDTransport stamps every request with a correlation ID, unless the caller already set one.
+type requestIDTransport struct {
+	next     http.RoundTripper
+	generate func() string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get(RequestIDHeader) == "" {
+		if id := t.generate(); id != "" {
+			req = req.Clone(req.Context())
+			req.Header.Set(RequestIDHeader, id)
+		}
+	}
+	return t.next.RoundTrip(req)
+}
+
+// requestID returns the request identifier CloudHealth returned for resp,
+// falling back to the correlation ID sent with the request.
+func requestID(resp *http.Response) string {
+	if id := resp.Header.Get(RequestIDHeader); id != "" {
+		return id
+	}
+	if resp.Request != nil {
+		return resp.Request.Header.Get(RequestIDHeader)
+	}
+	return ""
+}
@@ -0,0 +1,52 @@
+package cloudhealth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestIDSent(t *testing.T) {
+	var ids []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids = append(ids, r.Header.Get(RequestIDHeader))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	for i := 0; i < 2; i++ {
+		if err := c.DeleteAwsAccount(defaultAWSAccount.ID); err != nil {
+			t.Errorf("DeleteAwsAccount() returned an error: %s", err)
+			return
+		}
+	}
+	if len(ids) != 2 || ids[0] == "" || ids[0] == ids[1] {
+		t.Errorf("Expected a distinct request ID per request, got %v", ids)
+	}
+}
+
+func TestRequestIDInError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(RequestIDHeader, "upstream-"+r.Header.Get(RequestIDHeader))
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.RequestIDFunc = func() string { return "correlation-id" }
+
+	err = c.DeleteAwsAccount(defaultAWSAccount.ID)
+	if err == nil || !strings.Contains(err.Error(), "upstream-correlation-id") {
+		t.Errorf("DeleteAwsAccount() expected an error with the request ID, got: %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package cloudhealth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestClientURL(t *testing.T) {
+	c, err := NewClient("apiKey", WithEndpoint("https://chapi.cloudhealthtech.com/v1/"))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	u := c.url(apiCall{path: "aws_accounts", query: url.Values{"page": {"2"}}})
+	expected := "https://chapi.cloudhealthtech.com/v1/aws_accounts?page=2"
+	if u.String() != expected {
+		t.Errorf("url() expected `%s`, got `%s`", expected, u)
+	}
+}
+
+func TestSendStatusMapping(t *testing.T) {
+	errNotFound := errors.New("not found")
+	errConflict := errors.New("conflict")
+	tests := []struct {
+		status   int
+		expected error
+	}{
+		{http.StatusUnauthorized, ErrClientAuthenticationError},
+		{http.StatusForbidden, ErrClientAuthenticationError},
+		{http.StatusNotFound, errNotFound},
+		{http.StatusUnprocessableEntity, errConflict},
+	}
+	for _, test := range tests {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(test.status)
+		}))
+
+		c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+		if err != nil {
+			t.Errorf("NewClient() returned an error: %s", err)
+			ts.Close()
+			return
+		}
+		_, err = c.send(apiCall{
+			method:   "GET",
+			path:     "resource",
+			notFound: errNotFound,
+			errorFor: func(resp *http.Response) error {
+				if resp.StatusCode == http.StatusUnprocessableEntity {
+					return errConflict
+				}
+				return nil
+			},
+		})
+		var apiErr *APIError
+		if !errors.Is(err, test.expected) || !errors.As(err, &apiErr) || apiErr.StatusCode != test.status {
+			t.Errorf("send() expected an APIError wrapping `%v` for status %d, got `%v`", test.expected, test.status, err)
+		}
+		ts.Close()
+	}
+}
+
+func TestSendUnknownResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	_, err = get[AwsAccount](c, "resource", nil)
+	if err == nil || !strings.Contains(err.Error(), "418") {
+		t.Errorf("get() expected an unknown response error, got `%v`", err)
+	}
+}
+
+func TestDefaultUserAgent(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ua := r.Header.Get("User-Agent"); ua != "cloudhealth-sdk-go/"+Version {
+			t.Errorf("Expected User-Agent ‘cloudhealth-sdk-go/%s’, got ‘%s’", Version, ua)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if _, err := c.send(apiCall{method: "GET", path: "resource"}); err != nil {
+		t.Errorf("send() returned an error: %s", err)
+	}
+}
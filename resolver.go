@@ -0,0 +1,93 @@
+package cloudhealth
+
+import "sync"
+
+// ErrGroupNotFound is returned when resolving a group name a Perspective doesn't define.
+var ErrGroupNotFound error = notFoundError("Perspective group not found")
+
+// PerspectiveResolver resolves Perspective and group names to their IDs, caching the listing and the Perspectives
+// it retrieves so that resolving the same names repeatedly doesn't call CloudHealth again. Cached entries are kept
+// until invalidated. It is safe for concurrent use.
+type PerspectiveResolver struct {
+	client *Client
+
+	mu     sync.Mutex
+	ids    map[string]string            // active Perspective name to ID, nil until listed
+	groups map[string]map[string]string // Perspective ID to group name to ref_id
+}
+
+// NewPerspectiveResolver returns a PerspectiveResolver retrieving Perspectives with the Client.
+func (s *Client) NewPerspectiveResolver() *PerspectiveResolver {
+	return &PerspectiveResolver{client: s, groups: map[string]map[string]string{}}
+}
+
+// PerspectiveID returns the ID of the active Perspective with the given name.
+func (r *PerspectiveResolver) PerspectiveID(name string, opts ...CallOption) (id string, err error) {
+	defer annotate(&err, "PerspectiveID", name)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.ids == nil {
+		ids := map[string]string{}
+		err := r.client.perspectivesPages(defaultPerspectivesPerPage, func(page PerspectiveMap) bool {
+			for id, status := range page {
+				if status.Active {
+					ids[status.Name] = id
+				}
+			}
+			return true
+		}, opts)
+		if err != nil {
+			return "", err
+		}
+		r.ids = ids
+	}
+	id, ok := r.ids[name]
+	if !ok {
+		return "", ErrPerspectiveNotFound
+	}
+	return id, nil
+}
+
+// GroupRefID returns the ref_id of the static or dynamic group with the given name in the Perspective.
+func (r *PerspectiveResolver) GroupRefID(perspectiveID, group string, opts ...CallOption) (refID string, err error) {
+	defer annotate(&err, "GroupRefID", perspectiveID)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	groups, ok := r.groups[perspectiveID]
+	if !ok {
+		perspective, err := r.client.getPerspective(perspectiveID, opts)
+		if err != nil {
+			return "", err
+		}
+		groups = map[string]string{}
+		for _, constant := range perspective.Schema.Constants {
+			if constant.Type != StaticGroupType && constant.Type != DynamicGroupType {
+				continue
+			}
+			for _, item := range constant.List {
+				groups[item.Name] = item.RefID
+			}
+		}
+		r.groups[perspectiveID] = groups
+	}
+	refID, ok = groups[group]
+	if !ok {
+		return "", ErrGroupNotFound
+	}
+	return refID, nil
+}
+
+// Invalidate drops everything cached, e.g. after Perspectives were created, renamed or deleted.
+func (r *PerspectiveResolver) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ids = nil
+	r.groups = map[string]map[string]string{}
+}
+
+// InvalidatePerspective drops the cached groups of the Perspective, e.g. after it was updated.
+func (r *PerspectiveResolver) InvalidatePerspective(perspectiveID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.groups, perspectiveID)
+}
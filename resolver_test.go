@@ -0,0 +1,78 @@
+package cloudhealth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPerspectiveResolver(t *testing.T) {
+	requests := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.EscapedPath()]++
+		switch r.URL.EscapedPath() {
+		case "/perspective_schemas":
+			body, _ := json.Marshal(PerspectiveMap{
+				"1":                  {Name: "Teams", Active: false},
+				defaultPerspectiveID: {Name: "Teams", Active: true},
+			})
+			w.Write(body)
+		case fmt.Sprintf("/perspective_schemas/%s", defaultPerspectiveID):
+			body, _ := json.Marshal(Perspective{Schema: Schema{Name: "Teams", IncludeInReports: "true", Constants: []Constant{
+				{Type: StaticGroupType, List: []ConstantItem{{RefID: "1000", Name: "Platform"}, {RefID: "1001", Name: "Other", IsOther: "true"}}},
+				{Type: DynamicGroupBlockType, List: []ConstantItem{{RefID: "2000", Name: "Platform"}}},
+			}}})
+			w.Write(body)
+		default:
+			t.Errorf("Unexpected request to ‘%s’", r.URL.EscapedPath())
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	resolver := c.NewPerspectiveResolver()
+	for i := 0; i < 2; i++ {
+		id, err := resolver.PerspectiveID("Teams")
+		if err != nil {
+			t.Errorf("PerspectiveID() returned an error: %s", err)
+			return
+		}
+		if id != defaultPerspectiveID {
+			t.Errorf("PerspectiveID() expected the active Perspective %s, got %s", defaultPerspectiveID, id)
+		}
+		refID, err := resolver.GroupRefID(id, "Platform")
+		if err != nil {
+			t.Errorf("GroupRefID() returned an error: %s", err)
+			return
+		}
+		if refID != "1000" {
+			t.Errorf("GroupRefID() expected the static group 1000, got %s", refID)
+		}
+	}
+	if requests["/perspective_schemas"] != 1 || requests["/perspective_schemas/"+defaultPerspectiveID] != 1 {
+		t.Errorf("Expected each resource to be retrieved once, got %v", requests)
+	}
+
+	if _, err := resolver.PerspectiveID("Unknown"); !errors.Is(err, ErrPerspectiveNotFound) {
+		t.Errorf("PerspectiveID() returned the wrong error: %v", err)
+	}
+	if _, err := resolver.GroupRefID(defaultPerspectiveID, "Unknown"); !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("GroupRefID() returned the wrong error: %v", err)
+	}
+
+	resolver.Invalidate()
+	if _, err := resolver.PerspectiveID("Teams"); err != nil {
+		t.Errorf("PerspectiveID() returned an error: %s", err)
+	}
+	if requests["/perspective_schemas"] != 2 {
+		t.Errorf("Expected Invalidate() to make the listing be retrieved again, got %v", requests)
+	}
+}
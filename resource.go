@@ -0,0 +1,125 @@
+package cloudhealth
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// resource is a collection of T in the CloudHealth API, such as the AWS Accounts under "aws_accounts".
+// It gives every collection the same code path for reads, writes, pagination and error mapping.
+type resource[T any] struct {
+	path string
+	// notFound is returned when an item of the collection doesn't exist.
+	notFound error
+	// schema names the JSON Schema of the create and update payloads.
+	schema string
+	// maxBody, when set, returns the size in bytes above which create and update payloads are rejected before sending.
+	maxBody func(s *Client) int64
+}
+
+// maxBodyOf returns the payload size limit of the collection for s, zero when there is none.
+func (r resource[T]) maxBodyOf(s *Client) int64 {
+	if r.maxBody == nil {
+		return 0
+	}
+	return r.maxBody(s)
+}
+
+// itemPath returns the path of the item with the given ID.
+func (r resource[T]) itemPath(id string) string {
+	return r.path + "/" + id
+}
+
+// get retrieves the item with the given ID.
+func (r resource[T]) get(s *Client, id string, opts []CallOption) (*T, error) {
+	return get[T](s, r.itemPath(id), r.notFound, opts...)
+}
+
+// create posts a new item to the collection, expecting 201 Created.
+func (r resource[T]) create(s *Client, item interface{}, errorFor func(resp *http.Response) error, opts []CallOption) (*T, error) {
+	return do[T](s, apiCall{
+		method:   "POST",
+		path:     r.path,
+		body:     item,
+		schema:   r.schema,
+		maxBody:  r.maxBodyOf(s),
+		ok:       []int{http.StatusCreated},
+		errorFor: errorFor,
+		options:  opts,
+	})
+}
+
+// update replaces the item with the given ID.
+func (r resource[T]) update(s *Client, id string, item interface{}, errorFor func(resp *http.Response) error, opts []CallOption) (*T, error) {
+	return do[T](s, apiCall{
+		method:   "PUT",
+		path:     r.itemPath(id),
+		body:     item,
+		schema:   r.schema,
+		maxBody:  r.maxBodyOf(s),
+		notFound: r.notFound,
+		errorFor: errorFor,
+		options:  opts,
+	})
+}
+
+// delete removes the item with the given ID.
+func (r resource[T]) delete(s *Client, id string, query url.Values, opts []CallOption) error {
+	return s.del(r.itemPath(id), query, r.notFound, opts...)
+}
+
+// ListInterruptedError is returned when the context of a listing is done before its last page was retrieved.
+// The listing can be resumed with WithStartPage(NextPage).
+type ListInterruptedError struct {
+	NextPage int
+	Err      error // the context's error
+}
+
+// Error implements error.
+func (e *ListInterruptedError) Error() string {
+	return fmt.Sprintf("Listing interrupted before page %d: %s", e.NextPage, e.Err)
+}
+
+// Unwrap returns the context's error.
+func (e *ListInterruptedError) Unwrap() error {
+	return e.Err
+}
+
+// listPages retrieves the collection one page of perPage items at a time, decoding each page into P
+// and calling fn with its items until fn returns false or a short page signals the end of the collection.
+// The context of the call is checked between pages, a *ListInterruptedError being returned when it is done.
+func listPages[P any, R any, T any](s *Client, r resource[R], perPage int, items func(page *P) []T, fn func(page []T) bool, opts []CallOption) error {
+	settings := callOptions(opts)
+	// CloudHealth starts counting pages at 1 (but also accepts 0 which has results identical to 1)
+	pageNo := 1
+	if settings.startPage > 1 {
+		pageNo = settings.startPage
+	}
+	for ; ; pageNo++ {
+		if err := settings.ctx.Err(); err != nil {
+			return &ListInterruptedError{NextPage: pageNo, Err: err}
+		}
+		page, err := do[P](s, apiCall{
+			method: "GET",
+			path:   r.path,
+			query: url.Values{
+				"per_page": {strconv.Itoa(perPage)},
+				"page":     {strconv.Itoa(pageNo)},
+			},
+			notFound: r.notFound,
+			options:  opts,
+		})
+		if err != nil {
+			if ctxErr := settings.ctx.Err(); ctxErr != nil {
+				return &ListInterruptedError{NextPage: pageNo, Err: ctxErr}
+			}
+			return err
+		}
+		pageItems := items(page)
+		if !fn(pageItems) || len(pageItems) != perPage {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package cloudhealth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+type testItem struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type testItemsPage struct {
+	Items []testItem `json:"items"`
+}
+
+var errTestItemNotFound = errors.New("Test item not found")
+
+var testItems = resource[testItem]{path: "test_items", notFound: errTestItemNotFound}
+
+func TestResourceCRUD(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.EscapedPath() == "/test_items":
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == "DELETE" && r.URL.EscapedPath() == "/test_items/2":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		case r.URL.EscapedPath() == "/test_items/1":
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("Unexpected %s request to ‘%s’", r.Method, r.URL.EscapedPath())
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, _ := json.Marshal(testItem{ID: 1, Name: r.Method})
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if item, err := testItems.get(c, "1", nil); err != nil || item.Name != "GET" {
+		t.Errorf("get() returned %+v, %v", item, err)
+	}
+	if item, err := testItems.create(c, testItem{Name: "new"}, nil, nil); err != nil || item.Name != "POST" {
+		t.Errorf("create() returned %+v, %v", item, err)
+	}
+	if item, err := testItems.update(c, "1", testItem{ID: 1}, nil, nil); err != nil || item.Name != "PUT" {
+		t.Errorf("update() returned %+v, %v", item, err)
+	}
+	if err := testItems.delete(c, "1", nil, nil); err != nil {
+		t.Errorf("delete() returned an error: %s", err)
+	}
+	if err := testItems.delete(c, "2", nil, nil); !errors.Is(err, errTestItemNotFound) {
+		t.Errorf("delete() returned the wrong error: %v", err)
+	}
+}
+
+func TestResourceListPages(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		var items []testItem
+		// Three full pages followed by a short one
+		n := perPage
+		if page == 4 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			items = append(items, testItem{ID: (page-1)*perPage + i})
+		}
+		w.WriteHeader(http.StatusOK)
+		body, _ := json.Marshal(testItemsPage{Items: items})
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	var all []testItem
+	err = listPages(c, testItems, 2, func(page *testItemsPage) []testItem {
+		return page.Items
+	}, func(page []testItem) bool {
+		all = append(all, page...)
+		return true
+	}, nil)
+	if err != nil {
+		t.Errorf("listPages() returned an error: %s", err)
+		return
+	}
+	if len(all) != 7 || all[6].ID != 6 {
+		t.Errorf("listPages() returned unexpected items: %+v", all)
+	}
+}
+
+func TestListPagesInterrupted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var pages []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		if page == "3" {
+			cancel()
+			<-r.Context().Done()
+			return
+		}
+		json.NewEncoder(w).Encode(AwsAccounts{Accounts: []AwsAccount{{ID: len(pages)}}})
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	accounts, err := c.GetAllAwsAccounts(1, WithContext(ctx))
+	var interrupted *ListInterruptedError
+	if !errors.As(err, &interrupted) || interrupted.NextPage != 3 || !errors.Is(err, context.Canceled) {
+		t.Errorf("GetAllAwsAccounts() expected to be interrupted before page 3, got %v", err)
+		return
+	}
+	if len(accounts) != 2 {
+		t.Errorf("GetAllAwsAccounts() expected the 2 accounts retrieved, got %v", accounts)
+	}
+
+	pages = nil
+	c.GetAwsAccountsPages(1, func(page []AwsAccount) bool { return false }, WithStartPage(4))
+	if len(pages) != 1 || pages[0] != "4" {
+		t.Errorf("GetAwsAccountsPages() expected to resume at page 3, got pages %v", pages)
+	}
+}
@@ -0,0 +1,53 @@
+package cloudhealth
+
+import (
+	"net/http"
+	"time"
+)
+
+// ResponseMetadata is the status and headers of a response returned by CloudHealth.
+type ResponseMetadata struct {
+	Method     string
+	Endpoint   string // URL path of the request, without the query string
+	StatusCode int
+	Header     http.Header
+	RequestID  string
+}
+
+// LastResponse returns the metadata of the most recent response received by the Client, or nil before the first one.
+// Use StatsHook to observe every response when the Client is shared between goroutines.
+func (s *Client) LastResponse() *ResponseMetadata {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastResponse
+}
+
+// lastResponseTransport keeps the metadata and rate limit of the most recent response on the Client.
+type lastResponseTransport struct {
+	next   http.RoundTripper
+	client *Client
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *lastResponseTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	metadata := &ResponseMetadata{
+		Method:     req.Method,
+		Endpoint:   req.URL.Path,
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		RequestID:  requestID(resp),
+	}
+	rateLimit := parseRateLimit(resp.Header, time.Now())
+
+	t.client.mu.Lock()
+	t.client.lastResponse = metadata
+	if rateLimit != nil {
+		t.client.rateLimit = rateLimit
+	}
+	t.client.mu.Unlock()
+	return resp, err
+}
@@ -0,0 +1,42 @@
+package cloudhealth
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLastResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Custom", "value")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if c.LastResponse() != nil {
+		t.Errorf("Expected no LastResponse() before the first request")
+	}
+
+	if err := c.DeleteAwsAccount(defaultAWSAccount.ID); err != nil {
+		t.Errorf("DeleteAwsAccount() returned an error: %s", err)
+		return
+	}
+	last := c.LastResponse()
+	if last == nil {
+		t.Errorf("Expected LastResponse() after a request")
+		return
+	}
+	expectedEndpoint := fmt.Sprintf("/aws_accounts/%d", defaultAWSAccount.ID)
+	if last.Method != "DELETE" || last.Endpoint != expectedEndpoint || last.StatusCode != http.StatusNoContent {
+		t.Errorf("Unexpected LastResponse(): %+v", last)
+	}
+	if last.Header.Get("X-Custom") != "value" || last.RequestID == "" {
+		t.Errorf("Unexpected LastResponse(): %+v", last)
+	}
+}
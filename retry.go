@@ -0,0 +1,170 @@
+package cloudhealth
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy retries idempotent requests (GET, HEAD, PUT and DELETE) failing with a connection error, a timeout
+// or a 5xx status. Other requests aren't retried as CloudHealth may have processed them. Requests throttled with
+// 429 Too Many Requests are retried regardless of the policy, within the Client's RateLimitBudget; the policy's
+// backoff applies to them when CloudHealth doesn't tell how long to wait.
+type RetryPolicy struct {
+	MaxAttempts int           // attempts of a request, including the first; values below 2 disable retries
+	BaseDelay   time.Duration // wait before the first retry, doubled for every further retry
+	MaxDelay    time.Duration // upper bound of the wait; zero for none
+	// Jitter randomizes each wait by up to this fraction of it, from 0 (none) to 1 (anywhere from zero to
+	// the full wait), so clients throttled together don't retry in lockstep.
+	Jitter float64
+	Rand   func() float64 // source of randomness in [0, 1) for the jitter, defaulting to math/rand
+}
+
+// NewRetryPolicy returns a RetryPolicy making up to maxAttempts attempts, waiting baseDelay before the first retry
+// and doubling the wait up to maxDelay, randomized by half.
+func NewRetryPolicy(maxAttempts int, baseDelay, maxDelay time.Duration) *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: maxAttempts,
+		BaseDelay:   baseDelay,
+		MaxDelay:    maxDelay,
+		Jitter:      0.5,
+	}
+}
+
+// retryable reports whether the attempt numbered attempt, from 0, of a request with method that returned resp
+// and err should be retried.
+func (p *RetryPolicy) retryable(attempt int, method string, resp *http.Response, err error) bool {
+	if p == nil || attempt+1 >= p.MaxAttempts {
+		return false
+	}
+	if err != nil {
+		return idempotentMethod(method) && !errors.Is(err, ErrCircuitOpen)
+	}
+	return resp.StatusCode >= 500 && idempotentMethod(method)
+}
+
+// delay returns the wait before retrying after the attempt numbered attempt, from 0.
+func (p *RetryPolicy) delay(attempt int) time.Duration {
+	d := p.BaseDelay
+	for i := 0; i < attempt && (p.MaxDelay <= 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		random := rand.Float64
+		if p.Rand != nil {
+			random = p.Rand
+		}
+		d -= time.Duration(float64(d) * p.Jitter * random())
+	}
+	return d
+}
+
+func idempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// minThrottleWait is the least time waited before retrying a throttled request, even when told to retry at once.
+const minThrottleWait = time.Second
+
+// attempt describes which attempt of a request is being sent, for the statistics of the request.
+type attempt struct {
+	retries int
+	wait    time.Duration // waited before this attempt
+}
+
+type attemptKey struct{}
+
+// attemptOf returns the attempt of the request with ctx, the first one unless set by withAttempt.
+func attemptOf(ctx context.Context) attempt {
+	a, _ := ctx.Value(attemptKey{}).(attempt)
+	return a
+}
+
+func withAttempt(ctx context.Context, a attempt) context.Context {
+	return context.WithValue(ctx, attemptKey{}, a)
+}
+
+type callerKey struct{}
+
+// withCaller records ctx, the context of the caller, in itself so that it can be told apart from the contexts
+// derived from it by the http.Client, which are also done when the Client's Timeout elapses.
+func withCaller(ctx context.Context) context.Context {
+	return context.WithValue(ctx, callerKey{}, ctx)
+}
+
+// callerGaveUp reports whether the caller of the request with ctx canceled it or let its deadline pass, rather
+// than the request timing out on the Client's side.
+func callerGaveUp(ctx context.Context) bool {
+	caller, ok := ctx.Value(callerKey{}).(context.Context)
+	return ok && caller.Err() != nil
+}
+
+// doWithRetries sends the requests built by newRequest with hc, retrying failures according to the Client's RetryPolicy
+// and throttled requests within its RateLimitBudget, waiting between attempts with the Client's Clock. When the
+// request can't be retried, or ctx is done while waiting, the last response or error is returned.
+func (s *Client) doWithRetries(ctx context.Context, hc *http.Client, newRequest func(ctx context.Context) (*http.Request, error)) (*http.Response, error) {
+	var wait, throttled time.Duration
+	failures := 0
+	ctx = withCaller(ctx)
+	for n := 0; ; n++ {
+		// Hold off while another call of the Client is throttled, rather than be throttled too.
+		if pause := s.throttledFor(); pause > 0 && throttled+pause <= s.rateLimitBudget() {
+			if err := s.clock().Sleep(ctx, pause); err != nil {
+				return nil, err
+			}
+			throttled += pause
+		}
+		req, err := newRequest(withAttempt(ctx, attempt{retries: n, wait: wait}))
+		if err != nil {
+			return nil, err
+		}
+		finish := finishRequest(req)
+		resp, err := hc.Do(req)
+		finish()
+		switch {
+		case err == nil && resp.StatusCode == http.StatusTooManyRequests:
+			var ok bool
+			if wait, ok = throttleWait(resp.Header, s.clock().Now()); !ok {
+				wait = time.Second
+				if s.Retry != nil {
+					wait = s.Retry.delay(failures)
+				}
+				failures++
+			}
+			if wait < minThrottleWait {
+				wait = minThrottleWait
+			}
+			s.throttle(s.clock().Now().Add(wait))
+			if throttled+wait > s.rateLimitBudget() {
+				return resp, nil
+			}
+			throttled += wait
+		case err != nil && callerGaveUp(req.Context()):
+			// Requests timing out on the Client's Timeout are retried, but not those the caller gave up on.
+			return resp, err
+		case s.Retry.retryable(failures, req.Method, resp, err):
+			wait = s.Retry.delay(failures)
+			failures++
+		default:
+			return resp, err
+		}
+		if sleepErr := s.clock().Sleep(ctx, wait); sleepErr != nil {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package cloudhealth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	var delays []time.Duration
+	for attempt := 0; attempt < 5; attempt++ {
+		delays = append(delays, p.delay(attempt))
+	}
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	if !reflect.DeepEqual(delays, expected) {
+		t.Errorf("delay() expected %v, got %v", expected, delays)
+	}
+
+	p.Jitter = 0.5
+	p.Rand = func() float64 { return 0.5 }
+	if d := p.delay(1); d != 1500*time.Millisecond {
+		t.Errorf("delay() expected 1.5s with jitter, got %s", d)
+	}
+}
+
+func TestRetryServerErrors(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id":1,"name":"test"}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	clock := newFakeClock()
+	c.Clock = clock
+	c.Retry = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second}
+	var stats []RequestStats
+	c.StatsHook = func(s RequestStats) { stats = append(stats, s) }
+
+	if _, err := c.GetAwsAccount(1); err != nil {
+		t.Errorf("GetAwsAccount() returned an error: %s", err)
+		return
+	}
+	if expected := []time.Duration{time.Second, 2 * time.Second}; !reflect.DeepEqual(clock.sleeps, expected) {
+		t.Errorf("Expected waits %v, got %v", expected, clock.sleeps)
+	}
+	if len(stats) != 3 || stats[2].Retries != 2 || stats[2].Wait != 2*time.Second || stats[0].Retries != 0 {
+		t.Errorf("Expected stats of 3 attempts, got %+v", stats)
+	}
+}
+
+func TestRetryTimeouts(t *testing.T) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		w.Write([]byte(`{"id":1,"name":"test"}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL), WithTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.Clock = newFakeClock()
+	c.Retry = NewRetryPolicy(3, time.Second, 0)
+
+	if _, err := c.GetAwsAccount(1); err != nil {
+		t.Errorf("GetAwsAccount() returned an error: %s", err)
+		return
+	}
+	if attempts := atomic.LoadInt32(&attempts); attempts != 2 {
+		t.Errorf("Expected the timed out request to be retried once, got %d attempts", attempts)
+	}
+}
+
+func TestRetryCallerCanceled(t *testing.T) {
+	attempts := 0
+	ctx, cancel := context.WithCancel(context.Background())
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		cancel()
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.Clock = newFakeClock()
+	c.Retry = NewRetryPolicy(3, time.Second, 0)
+
+	if _, err := c.GetAwsAccount(1, WithContext(ctx)); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetAwsAccount() expected context.Canceled, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected no retry once the caller gave up, got %d attempts", attempts)
+	}
+}
+
+func TestRetryExhausted(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.Clock = newFakeClock()
+	c.Retry = NewRetryPolicy(2, time.Second, time.Minute)
+
+	_, err = c.GetAwsAccount(1)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway || attempts != 2 {
+		t.Errorf("GetAwsAccount() expected a 502 APIError after 2 attempts, got %v after %d", err, attempts)
+	}
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	statuses := []int{http.StatusTooManyRequests, http.StatusInternalServerError}
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+		}
+		w.WriteHeader(statuses[attempts])
+		attempts++
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.Clock = newFakeClock()
+	c.Retry = &RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second}
+
+	_, err = c.CreatePerspective(&defaultPerspective)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError || attempts != 2 {
+		t.Errorf("CreatePerspective() expected to retry the 429 but not the 500, got %v after %d attempts", err, attempts)
+	}
+}
+
+func TestRetryDisabledByDefault(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if _, err := c.GetAwsAccount(1); err == nil || attempts != 1 {
+		t.Errorf("GetAwsAccount() expected to fail after a single attempt, got %v after %d", err, attempts)
+	}
+}
@@ -0,0 +1,91 @@
+package cloudhealth
+
+import "context"
+
+// AwsAccountsService groups the AWS Account methods of a Client, reached through Client.AwsAccounts.
+type AwsAccountsService struct {
+	client *Client
+}
+
+// AwsAccounts returns the AWS Account methods of the Client, e.g. client.AwsAccounts().Get(ctx, id).
+func (s *Client) AwsAccounts() *AwsAccountsService {
+	return &AwsAccountsService{client: s}
+}
+
+// defaultAwsAccountsPerPage is the page size AwsAccountsService.List lists AWS Accounts with.
+const defaultAwsAccountsPerPage = 100
+
+// List gets all AWS Accounts, like GetAllAwsAccounts.
+func (s *AwsAccountsService) List(ctx context.Context, opts ...CallOption) ([]AwsAccount, error) {
+	return s.client.GetAllAwsAccounts(defaultAwsAccountsPerPage, withCallContext(ctx, opts)...)
+}
+
+// Get gets the AWS Account with the specified CloudHealth Account ID, like GetAwsAccount.
+func (s *AwsAccountsService) Get(ctx context.Context, id int, opts ...CallOption) (*AwsAccount, error) {
+	return s.client.GetAwsAccount(id, withCallContext(ctx, opts)...)
+}
+
+// Create enables a new AWS Account in CloudHealth, like CreateAwsAccount.
+func (s *AwsAccountsService) Create(ctx context.Context, account AwsAccount, opts ...CallOption) (*AwsAccount, error) {
+	return s.client.CreateAwsAccount(account, withCallContext(ctx, opts)...)
+}
+
+// Update updates an existing AWS Account in CloudHealth, like UpdateAwsAccount.
+func (s *AwsAccountsService) Update(ctx context.Context, account AwsAccount, opts ...CallOption) (*AwsAccount, error) {
+	return s.client.UpdateAwsAccount(account, withCallContext(ctx, opts)...)
+}
+
+// Delete removes the AWS Account with the specified CloudHealth Account ID, like DeleteAwsAccount.
+func (s *AwsAccountsService) Delete(ctx context.Context, id int, opts ...CallOption) error {
+	return s.client.DeleteAwsAccount(id, withCallContext(ctx, opts)...)
+}
+
+// ExternalID gets the AWS External ID tied to the CloudHealth Account, like GetAwsExternalID.
+func (s *AwsAccountsService) ExternalID(ctx context.Context, opts ...CallOption) (string, error) {
+	return s.client.GetAwsExternalID(withCallContext(ctx, opts)...)
+}
+
+// PerspectivesService groups the Perspective methods of a Client, reached through Client.Perspectives.
+type PerspectivesService struct {
+	client *Client
+}
+
+// Perspectives returns the Perspective methods of the Client, e.g. client.Perspectives().List(ctx).
+func (s *Client) Perspectives() *PerspectivesService {
+	return &PerspectivesService{client: s}
+}
+
+// List gets the ID, name and state of all Perspectives, like GetAllPerspectives.
+func (s *PerspectivesService) List(ctx context.Context, opts ...CallOption) (*PerspectiveMap, error) {
+	return s.client.GetAllPerspectives(withCallContext(ctx, opts)...)
+}
+
+// Get gets the Perspective with the specified ID, like GetPerspective.
+func (s *PerspectivesService) Get(ctx context.Context, id string, opts ...CallOption) (*Perspective, error) {
+	return s.client.GetPerspective(id, withCallContext(ctx, opts)...)
+}
+
+// Create creates a Perspective and returns its ID, like CreatePerspective.
+func (s *PerspectivesService) Create(ctx context.Context, perspective *Perspective, opts ...CallOption) (string, error) {
+	return s.client.CreatePerspective(perspective, withCallContext(ctx, opts)...)
+}
+
+// Update replaces the Perspective with the specified ID, like UpdatePerspective.
+func (s *PerspectivesService) Update(ctx context.Context, id string, perspective *Perspective, opts ...CallOption) (*Perspective, error) {
+	return s.client.UpdatePerspective(id, perspective, withCallContext(ctx, opts)...)
+}
+
+// Delete deletes the Perspective with the specified ID, like DeletePerspective.
+func (s *PerspectivesService) Delete(ctx context.Context, id string, opts ...CallOption) error {
+	return s.client.DeletePerspective(id, withCallContext(ctx, opts)...)
+}
+
+// Archive archives the Perspective with the specified ID, like ArchivePerspective.
+func (s *PerspectivesService) Archive(ctx context.Context, id string, opts ...CallOption) error {
+	return s.client.ArchivePerspective(id, withCallContext(ctx, opts)...)
+}
+
+// withCallContext prepends WithContext(ctx) to opts, so that an explicit WithContext among them still wins.
+func withCallContext(ctx context.Context, opts []CallOption) []CallOption {
+	return append([]CallOption{WithContext(ctx)}, opts...)
+}
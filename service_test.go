@@ -0,0 +1,83 @@
+package cloudhealth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestServices(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/aws_accounts/1234":
+			w.Write([]byte(`{"id":1234,"name":"test"}`))
+		case "/perspective_schemas/5678":
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	ctx := context.Background()
+
+	account, err := c.AwsAccounts().Get(ctx, 1234)
+	if err != nil {
+		t.Errorf("AwsAccounts().Get() returned an error: %s", err)
+		return
+	}
+	if account.ID != 1234 {
+		t.Errorf("AwsAccounts().Get() expected ID 1234, got %d", account.ID)
+	}
+	if err := c.Perspectives().Delete(ctx, "5678"); err != nil {
+		t.Errorf("Perspectives().Delete() returned an error: %s", err)
+	}
+	if len(requests) != 2 || requests[1] != "DELETE /perspective_schemas/5678" {
+		t.Errorf("Unexpected requests: %v", requests)
+	}
+}
+
+func TestServicesContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request with a canceled context")
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := c.Perspectives().Get(ctx, "5678"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Perspectives().Get() expected context.Canceled, got %v", err)
+	}
+	if _, err := c.AwsAccounts().List(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("AwsAccounts().List() expected context.Canceled, got %v", err)
+	}
+}
+
+func TestServicesClientLiteral(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":1234,"name":"test"}`))
+	}))
+	defer ts.Close()
+
+	endpoint, _ := url.Parse(ts.URL)
+	c := &Client{ApiKey: "apiKey", EndpointURL: endpoint}
+	if _, err := c.AwsAccounts().Get(context.Background(), 1234); err != nil {
+		t.Errorf("AwsAccounts().Get() returned an error: %s", err)
+	}
+}
@@ -0,0 +1,40 @@
+package cloudhealth
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogStatsHook returns a StatsHook logging every request to logger at debug level,
+// or at warn level when the request failed or CloudHealth answered with an error status.
+func SlogStatsHook(logger *slog.Logger) func(RequestStats) {
+	return func(stats RequestStats) {
+		level := slog.LevelDebug
+		if stats.Err != nil || stats.StatusCode >= 400 {
+			level = slog.LevelWarn
+		}
+		attrs := []slog.Attr{
+			slog.String("method", stats.Method),
+			slog.String("endpoint", stats.Endpoint),
+			slog.String("url", stats.URL),
+			slog.Int("status", stats.StatusCode),
+			slog.Duration("duration", stats.Latency),
+			slog.Int("attempt", stats.Retries+1),
+		}
+		if stats.Wait > 0 {
+			attrs = append(attrs, slog.Duration("wait", stats.Wait))
+		}
+		if stats.Err != nil {
+			attrs = append(attrs, slog.String("error", stats.Err.Error()))
+		}
+		logger.LogAttrs(context.Background(), level, "cloudhealth request", attrs...)
+	}
+}
+
+// WithLogger logs every request to logger like SlogStatsHook, after calling any StatsHook already set.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(s *Client) error {
+		s.addStatsHook(SlogStatsHook(logger))
+		return nil
+	}
+}
@@ -0,0 +1,77 @@
+package cloudhealth
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSlogStatsHook(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.StatsHook = SlogStatsHook(logger)
+	c.DeleteAwsAccount(defaultAWSAccount.ID)
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Errorf("Unable to decode log record `%s`: %s", buf.Bytes(), err)
+		return
+	}
+	if record["level"] != "WARN" || record["method"] != "DELETE" || record["status"] != float64(http.StatusNotFound) {
+		t.Errorf("Unexpected log record: %v", record)
+	}
+	if record["attempt"] != float64(1) || record["endpoint"] == "" {
+		t.Errorf("Unexpected log record: %v", record)
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	hooked := 0
+	c, err := NewClient("secretApiKey", WithEndpoint(ts.URL), func(s *Client) error {
+		s.StatsHook = func(RequestStats) { hooked++ }
+		s.AuthMode = AuthAPIKey
+		return nil
+	}, WithLogger(logger))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.DeleteAwsAccount(defaultAWSAccount.ID)
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Errorf("Unable to decode log record `%s`: %s", buf.Bytes(), err)
+		return
+	}
+	if record["level"] != "DEBUG" || record["url"] != fmt.Sprintf("%s/aws_accounts/%d", ts.URL, defaultAWSAccount.ID) {
+		t.Errorf("Unexpected log record: %v", record)
+	}
+	if bytes.Contains(buf.Bytes(), []byte("secretApiKey")) {
+		t.Errorf("Expected the API key to be redacted from the log: %s", buf.Bytes())
+	}
+	if hooked != 1 {
+		t.Errorf("Expected the StatsHook set before WithLogger to be kept")
+	}
+}
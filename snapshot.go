@@ -0,0 +1,189 @@
+package cloudhealth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// PerspectiveSnapshotVersion is the version of the snapshot format written by SnapshotPerspectives.
+const PerspectiveSnapshotVersion = 1
+
+// PerspectiveManifestFile is the name of the manifest in a snapshot directory.
+const PerspectiveManifestFile = "manifest.json"
+
+// PerspectiveManifest describes the Perspectives saved in a snapshot directory.
+type PerspectiveManifest struct {
+	Version      int                   `json:"version"`
+	CreatedAt    time.Time             `json:"created_at"`
+	Perspectives []SnapshotPerspective `json:"perspectives"`
+}
+
+// SnapshotPerspective is the manifest entry of a saved Perspective.
+type SnapshotPerspective struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	File   string `json:"file"`   // relative to the snapshot directory
+	SHA256 string `json:"sha256"` // of the file contents
+}
+
+// SnapshotPerspectives saves every active Perspective to dir, one JSON file per Perspective named after its ID,
+// along with a manifest recording when the snapshot was taken and a checksum of each file. opts apply to every
+// request made.
+func (s *Client) SnapshotPerspectives(dir string, opts ...CallOption) (manifest *PerspectiveManifest, err error) {
+	defer annotate(&err, "SnapshotPerspectives", dir)
+	perspectives, err := s.GetAllPerspectives(opts...)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(*perspectives))
+	for id, status := range *perspectives {
+		if status.Active {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	manifest = &PerspectiveManifest{
+		Version:      PerspectiveSnapshotVersion,
+		CreatedAt:    s.clock().Now().UTC(),
+		Perspectives: []SnapshotPerspective{},
+	}
+	for _, id := range ids {
+		perspective, err := s.GetPerspective(id, opts...)
+		if err != nil {
+			return nil, err
+		}
+		entry := SnapshotPerspective{ID: id, Name: perspective.Schema.Name, File: id + ".json"}
+		if entry.SHA256, err = writeJSONFile(filepath.Join(dir, entry.File), perspective); err != nil {
+			return nil, err
+		}
+		manifest.Perspectives = append(manifest.Perspectives, entry)
+	}
+	if _, err := writeJSONFile(filepath.Join(dir, PerspectiveManifestFile), manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// writeJSONFile writes v as indented JSON to path, returning the hex SHA-256 of the contents.
+func writeJSONFile(path string, v interface{}) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	data = append(data, '\n')
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// RestoreOptions configures RestorePerspectives.
+type RestoreOptions struct {
+	// DryRun reports what would be restored without changing any Perspective.
+	DryRun bool
+}
+
+// Actions taken by RestorePerspectives.
+const (
+	RestoreCreate = "create"
+	RestoreUpdate = "update"
+)
+
+// PerspectiveRestore is the outcome of restoring a saved Perspective with RestorePerspectives.
+type PerspectiveRestore struct {
+	SnapshotID string // ID of the Perspective when the snapshot was taken
+	Name       string
+	Action     string // RestoreCreate or RestoreUpdate
+	TargetID   string // ID of the restored Perspective, empty when a dry run creates it
+	Err        error
+}
+
+// RestorePerspectives recreates or updates the Perspectives saved by SnapshotPerspectives in dir. A saved Perspective
+// updates the active Perspective with the same ID, or else the same name, and is created when there is neither.
+// Files not matching their manifest checksum are not restored. Every Perspective is attempted, failures being
+// reported in the results, unless the context of opts is done, which stops the restore. opts apply to every
+// request made.
+func (s *Client) RestorePerspectives(dir string, restore RestoreOptions, opts ...CallOption) (results []PerspectiveRestore, err error) {
+	defer annotate(&err, "RestorePerspectives", dir)
+	data, err := os.ReadFile(filepath.Join(dir, PerspectiveManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	manifest := new(PerspectiveManifest)
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, err
+	}
+	if manifest.Version != PerspectiveSnapshotVersion {
+		return nil, fmt.Errorf("Unsupported snapshot version %d", manifest.Version)
+	}
+
+	existing, err := s.GetAllPerspectives(opts...)
+	if err != nil {
+		return nil, err
+	}
+	byName := map[string]string{}
+	for id, status := range *existing {
+		if status.Active {
+			byName[status.Name] = id
+		}
+	}
+
+	ctx := callOptions(opts).ctx
+	results = make([]PerspectiveRestore, 0, len(manifest.Perspectives))
+	for _, entry := range manifest.Perspectives {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		result := PerspectiveRestore{SnapshotID: entry.ID, Name: entry.Name}
+		perspective, err := readSnapshotFile(filepath.Join(dir, entry.File), entry.SHA256)
+		if err != nil {
+			result.Err = err
+			results = append(results, result)
+			continue
+		}
+
+		result.Action = RestoreCreate
+		if status, ok := (*existing)[entry.ID]; ok && status.Active {
+			result.Action, result.TargetID = RestoreUpdate, entry.ID
+		} else if id, ok := byName[perspective.Schema.Name]; ok {
+			result.Action, result.TargetID = RestoreUpdate, id
+		}
+		if !restore.DryRun {
+			if result.Action == RestoreUpdate {
+				_, result.Err = s.UpdatePerspective(result.TargetID, perspective, opts...)
+			} else {
+				result.TargetID, result.Err = s.CreatePerspective(perspective, opts...)
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// readSnapshotFile reads the saved Perspective at path, checking the contents against the hex SHA-256 checksum.
+func readSnapshotFile(path, checksum string) (*Perspective, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != checksum {
+		return nil, fmt.Errorf("Snapshot file %s doesn't match its checksum", path)
+	}
+	perspective := new(Perspective)
+	if err := json.Unmarshal(data, perspective); err != nil {
+		return nil, err
+	}
+	return perspective, nil
+}
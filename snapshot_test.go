@@ -0,0 +1,193 @@
+package cloudhealth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSnapshotPerspectives(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/perspective_schemas":
+			body, _ := json.Marshal(PerspectiveMap{
+				"11": {Name: "Team", Active: true},
+				"10": {Name: "Environment", Active: true},
+				"12": {Name: "Archived", Active: false},
+			})
+			w.Write(body)
+		case "/perspective_schemas/10", "/perspective_schemas/11":
+			body, _ := json.Marshal(Perspective{Schema: Schema{Name: "perspective " + r.URL.Path[len("/perspective_schemas/"):], IncludeInReports: "true"}})
+			w.Write(body)
+		default:
+			t.Errorf("Unexpected request to ‘%s’", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.Clock = newFakeClock()
+	dir := filepath.Join(t.TempDir(), "snapshot")
+
+	manifest, err := c.SnapshotPerspectives(dir)
+	if err != nil {
+		t.Errorf("SnapshotPerspectives() returned an error: %s", err)
+		return
+	}
+	if len(manifest.Perspectives) != 2 || manifest.Perspectives[0].ID != "10" || manifest.Perspectives[1].ID != "11" {
+		t.Errorf("SnapshotPerspectives() expected the active Perspectives 10 and 11, got %+v", manifest.Perspectives)
+		return
+	}
+	if !manifest.CreatedAt.Equal(c.Clock.Now()) || manifest.Version != PerspectiveSnapshotVersion {
+		t.Errorf("SnapshotPerspectives() returned an unexpected manifest: %+v", manifest)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, PerspectiveManifestFile))
+	if err != nil {
+		t.Errorf("Unable to read the manifest: %s", err)
+		return
+	}
+	saved := new(PerspectiveManifest)
+	if err := json.Unmarshal(data, saved); err != nil || len(saved.Perspectives) != 2 {
+		t.Errorf("Unable to decode the manifest `%s`: %v", data, err)
+	}
+	perspective := new(Perspective)
+	data, _ = os.ReadFile(filepath.Join(dir, "10.json"))
+	if err := json.Unmarshal(data, perspective); err != nil || perspective.Schema.Name != "perspective 10" {
+		t.Errorf("Unexpected Perspective file `%s`: %v", data, err)
+	}
+}
+
+func TestSnapshotPerspectivesOptions(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if auth := r.Header.Get("Authorization"); auth != "Bearer tenantKey" {
+			t.Errorf("Expected the API key of the call on ‘%s’, got ‘%s’", r.URL.Path, auth)
+		}
+		if r.URL.Path == "/perspective_schemas" {
+			w.Write([]byte(`{"10":{"name":"Environment","active":true}}`))
+			return
+		}
+		w.Write([]byte(`{"schema":{"name":"Environment","include_in_reports":"true"}}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if _, err := c.SnapshotPerspectives(t.TempDir(), WithApiKey("tenantKey")); err != nil {
+		t.Errorf("SnapshotPerspectives() returned an error: %s", err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.SnapshotPerspectives(t.TempDir(), WithContext(ctx)); !errors.Is(err, context.Canceled) {
+		t.Errorf("SnapshotPerspectives() expected context.Canceled, got %v", err)
+	}
+}
+
+func TestRestorePerspectives(t *testing.T) {
+	dir := t.TempDir()
+	manifest := PerspectiveManifest{Version: PerspectiveSnapshotVersion}
+	for _, p := range []struct{ id, name string }{{"10", "Environment"}, {"11", "Team"}, {"12", "Cost Center"}, {"13", "Corrupted"}} {
+		sum, err := writeJSONFile(filepath.Join(dir, p.id+".json"), Perspective{Schema: Schema{Name: p.name, IncludeInReports: "true"}})
+		if err != nil {
+			t.Errorf("Unable to write the snapshot: %s", err)
+			return
+		}
+		manifest.Perspectives = append(manifest.Perspectives, SnapshotPerspective{ID: p.id, Name: p.name, File: p.id + ".json", SHA256: sum})
+	}
+	manifest.Perspectives[3].SHA256 = "0000"
+	writeJSONFile(filepath.Join(dir, PerspectiveManifestFile), manifest)
+
+	var changes, auths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			body, _ := json.Marshal(PerspectiveMap{
+				"10": {Name: "Environment", Active: true},
+				"20": {Name: "Team", Active: true},
+			})
+			w.Write(body)
+		case "PUT":
+			changes = append(changes, r.Method+" "+r.URL.Path)
+			auths = append(auths, r.Header.Get("Authorization"))
+			w.Write([]byte(`{"schema":{}}`))
+		case "POST":
+			changes = append(changes, r.Method+" "+r.URL.Path)
+			auths = append(auths, r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte("Perspective 30 created"))
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	results, err := c.RestorePerspectives(dir, RestoreOptions{DryRun: true})
+	if err != nil {
+		t.Errorf("RestorePerspectives() returned an error: %s", err)
+		return
+	}
+	if len(changes) != 0 {
+		t.Errorf("RestorePerspectives() made changes during a dry run: %v", changes)
+	}
+	expected := []PerspectiveRestore{
+		{SnapshotID: "10", Name: "Environment", Action: RestoreUpdate, TargetID: "10"},
+		{SnapshotID: "11", Name: "Team", Action: RestoreUpdate, TargetID: "20"},
+		{SnapshotID: "12", Name: "Cost Center", Action: RestoreCreate},
+	}
+	for i, e := range expected {
+		if results[i] != e {
+			t.Errorf("RestorePerspectives() dry run expected %+v, got %+v", e, results[i])
+		}
+	}
+	if results[3].Err == nil {
+		t.Errorf("RestorePerspectives() expected a checksum error, got %+v", results[3])
+	}
+
+	results, err = c.RestorePerspectives(dir, RestoreOptions{}, WithApiKey("tenantKey"))
+	if err != nil {
+		t.Errorf("RestorePerspectives() returned an error: %s", err)
+		return
+	}
+	if results[2].TargetID != "30" || results[2].Err != nil {
+		t.Errorf("RestorePerspectives() expected Cost Center to be created as 30, got %+v", results[2])
+	}
+	expectedChanges := []string{"PUT /perspective_schemas/10", "PUT /perspective_schemas/20", "POST /perspective_schemas/"}
+	if !reflect.DeepEqual(changes, expectedChanges) {
+		t.Errorf("RestorePerspectives() expected changes %v, got %v", expectedChanges, changes)
+	}
+	for _, auth := range auths {
+		if auth != "Bearer tenantKey" {
+			t.Errorf("RestorePerspectives() expected changes made with the API key of the call, got ‘%s’", auth)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.RestorePerspectives(dir, RestoreOptions{}, WithContext(ctx)); !errors.Is(err, context.Canceled) {
+		t.Errorf("RestorePerspectives() expected context.Canceled, got %v", err)
+	}
+}
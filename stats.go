@@ -0,0 +1,50 @@
+package cloudhealth
+
+import (
+	"net/http"
+	"time"
+)
+
+// RequestStats describes a completed request to the CloudHealth API.
+type RequestStats struct {
+	Method     string
+	Endpoint   string // URL path of the request, without the query string
+	URL        string // URL of the request, without the api_key query parameter
+	StatusCode int    // zero when no response was received
+	Header     http.Header
+	RateLimit  *RateLimit    // nil when the response didn't report a quota
+	Retries    int           // attempts of the request made before this one under the Client's RetryPolicy
+	Wait       time.Duration // waited after the previous attempt
+	Latency    time.Duration
+	Err        error
+}
+
+// statsTransport reports the outcome of every request to a stats hook.
+type statsTransport struct {
+	next http.RoundTripper
+	hook func(RequestStats)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *statsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+
+	attempt := attemptOf(req.Context())
+	stats := RequestStats{
+		Method:   req.Method,
+		Endpoint: req.URL.Path,
+		URL:      redactedURL(req.URL),
+		Retries:  attempt.retries,
+		Wait:     attempt.wait,
+		Latency:  time.Since(start),
+		Err:      err,
+	}
+	if resp != nil {
+		stats.StatusCode = resp.StatusCode
+		stats.Header = resp.Header
+		stats.RateLimit = parseRateLimit(resp.Header, time.Now())
+	}
+	t.hook(stats)
+	return resp, err
+}
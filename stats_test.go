@@ -0,0 +1,44 @@
+package cloudhealth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStatsHook(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	var calls []RequestStats
+	c.StatsHook = func(stats RequestStats) {
+		calls = append(calls, stats)
+	}
+
+	_, err = c.GetAwsAccount(defaultAWSAccount.ID)
+	if !errors.Is(err, ErrAwsAccountNotFound) {
+		t.Errorf("GetAwsAccount() returned the wrong error: %s", err)
+		return
+	}
+	if len(calls) != 1 {
+		t.Errorf("Expected StatsHook to be called once, got %d", len(calls))
+		return
+	}
+	stats := calls[0]
+	expectedEndpoint := fmt.Sprintf("/aws_accounts/%d", defaultAWSAccount.ID)
+	if stats.Method != "GET" || stats.Endpoint != expectedEndpoint || stats.StatusCode != http.StatusNotFound {
+		t.Errorf("Unexpected RequestStats: %+v", stats)
+	}
+	if stats.Latency <= 0 || stats.Err != nil {
+		t.Errorf("Unexpected RequestStats: %+v", stats)
+	}
+}
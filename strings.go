@@ -0,0 +1,39 @@
+package cloudhealth
+
+import "fmt"
+
+// String returns a concise summary of the AWS Account, without credentials.
+func (a AwsAccount) String() string {
+	s := fmt.Sprintf("AWS Account %d %q (%s)", a.ID, a.Name, a.Authentication)
+	if a.Status != nil {
+		s += fmt.Sprintf(" status %s", a.Status)
+	}
+	return s
+}
+
+// String returns the authentication protocol and role, never the secret key or external ID.
+func (a AwsAccountAuthentication) String() string {
+	switch {
+	case a.AssumeRoleArn != "":
+		return fmt.Sprintf("%s %s", a.Protocol, a.AssumeRoleArn)
+	case a.AccessKey != "":
+		return fmt.Sprintf("%s %s", a.Protocol, a.AccessKey)
+	case a.Protocol != "":
+		return a.Protocol
+	default:
+		return "no authentication"
+	}
+}
+
+// String returns the status level and when it was last updated.
+func (s AwsAccountStatus) String() string {
+	if s.LastUpdate == "" {
+		return s.Level
+	}
+	return fmt.Sprintf("%s (updated %s)", s.Level, s.LastUpdate)
+}
+
+// String returns the perspective name and the size of its schema.
+func (p Perspective) String() string {
+	return fmt.Sprintf("Perspective %q (%d rules, %d constants)", p.Schema.Name, len(p.Schema.Rules), len(p.Schema.Constants))
+}
@@ -0,0 +1,52 @@
+package cloudhealth
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestAwsAccountStringHidesSecrets(t *testing.T) {
+	account := AwsAccount{
+		ID:   1234567890,
+		Name: "test",
+		Authentication: AwsAccountAuthentication{
+			Protocol:  "access_key",
+			AccessKey: "AKIAEXAMPLE",
+			SecretKey: "supersecret",
+		},
+		Status: &AwsAccountStatus{Level: "green", LastUpdate: "2020-04-12T08:15:32Z"},
+	}
+	expected := `AWS Account 1234567890 "test" (access_key AKIAEXAMPLE) status green (updated 2020-04-12T08:15:32Z)`
+	for _, s := range []string{account.String(), fmt.Sprintf("%v", &account), fmt.Sprintf("%+v", account)} {
+		if s != expected {
+			t.Errorf("String() expected `%s`, got `%s`", expected, s)
+		}
+		if strings.Contains(s, "supersecret") {
+			t.Errorf("String() leaked the secret key: %s", s)
+		}
+	}
+
+	role := AwsAccountAuthentication{
+		Protocol:             "assume_role",
+		AssumeRoleArn:        "arn:aws:iam::123456789012:role/CloudHealth",
+		AssumeRoleExternalID: "externalid",
+	}
+	if s := role.String(); strings.Contains(s, "externalid") {
+		t.Errorf("String() leaked the external ID: %s", s)
+	}
+}
+
+func TestPerspectiveString(t *testing.T) {
+	perspective := Perspective{
+		Schema: Schema{
+			Name:      "test",
+			Rules:     []Rule{{Type: "filter"}},
+			Constants: []Constant{*NewConstant(StaticGroupType)},
+		},
+	}
+	expected := `Perspective "test" (1 rules, 1 constants)`
+	if perspective.String() != expected {
+		t.Errorf("String() expected `%s`, got `%s`", expected, perspective.String())
+	}
+}
@@ -0,0 +1,46 @@
+package cloudhealth
+
+import (
+	"regexp"
+	"strings"
+)
+
+// iamRoleArnPattern matches the ARN of an IAM Role in any AWS partition.
+var iamRoleArnPattern = regexp.MustCompile(`^arn:aws(-[a-z]+)*:iam::\d{12}:role/.+$`)
+
+// Validate checks the AWS Account for mistakes CloudHealth would reject, returning a *ValidationError
+// without any network call.
+func (a AwsAccount) Validate() error {
+	return a.validate(false)
+}
+
+// validate checks the AWS Account like Validate, allowing what an update of an existing account may leave out.
+func (a AwsAccount) validate(update bool) error {
+	var problems []ValidationProblem
+	if strings.TrimSpace(a.Name) == "" {
+		problems = append(problems, ValidationProblem{Path: "$.name", Message: "must not be empty"})
+	}
+	if err := a.Authentication.validate(update); err != nil {
+		problems = append(problems, err.(*ValidationError).Problems...)
+	}
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// Validate checks the Perspective for mistakes CloudHealth would reject, returning a *ValidationError
+// without any network call.
+func (p Perspective) Validate() error {
+	var problems []ValidationProblem
+	if strings.TrimSpace(p.Schema.Name) == "" {
+		problems = append(problems, ValidationProblem{Path: "$.schema.name", Message: "must not be empty"})
+	}
+	if p.Schema.IncludeInReports != "true" && p.Schema.IncludeInReports != "false" {
+		problems = append(problems, ValidationProblem{Path: "$.schema.include_in_reports", Message: `must be "true" or "false"`})
+	}
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
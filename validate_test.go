@@ -0,0 +1,63 @@
+package cloudhealth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAwsAccountValidate(t *testing.T) {
+	tests := []struct {
+		account AwsAccount
+		valid   bool
+	}{
+		{AwsAccount{Name: "test"}, true},
+		{AwsAccount{Name: "test", Authentication: NewAssumeRoleAuth("arn:aws:iam::123456789012:role/CloudHealth", "id")}, true},
+		{AwsAccount{Name: "test", Authentication: NewAssumeRoleAuth("arn:aws-us-gov:iam::123456789012:role/path/CloudHealth", "id")}, true},
+		{AwsAccount{Name: "test", Authentication: NewAssumeRoleAuth("arn:aws:iam::1234:role/CloudHealth", "id")}, false},
+		{AwsAccount{Name: "test", Authentication: NewAssumeRoleAuth("arn:aws:iam::123456789012:user/CloudHealth", "id")}, false},
+		{AwsAccount{Name: " "}, false},
+	}
+	for _, test := range tests {
+		if err := test.account.Validate(); (err == nil) != test.valid {
+			t.Errorf("Validate() for %s returned %v", test.account, err)
+		}
+	}
+}
+
+func TestPerspectiveValidate(t *testing.T) {
+	tests := []struct {
+		perspective Perspective
+		valid       bool
+	}{
+		{Perspective{Schema: Schema{Name: "test", IncludeInReports: "true"}}, true},
+		{Perspective{Schema: Schema{Name: "test", IncludeInReports: "false"}}, true},
+		{Perspective{Schema: Schema{Name: "test", IncludeInReports: "yes"}}, false},
+		{Perspective{Schema: Schema{IncludeInReports: "true"}}, false},
+	}
+	for _, test := range tests {
+		if err := test.perspective.Validate(); (err == nil) != test.valid {
+			t.Errorf("Validate() for %s returned %v", test.perspective, err)
+		}
+	}
+}
+
+func TestValidateBeforeNetworkCall(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected %s request to ‘%s’", r.Method, r.URL.EscapedPath())
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if _, err := c.CreateAwsAccount(AwsAccount{}); err == nil {
+		t.Errorf("CreateAwsAccount() expected a validation error")
+	}
+	if _, err := c.UpdatePerspective(defaultPerspectiveID, &Perspective{}); err == nil {
+		t.Errorf("UpdatePerspective() expected a validation error")
+	}
+}
@@ -0,0 +1,26 @@
+package cloudhealth
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// Warmup pre-establishes the connection to CloudHealth, including the TLS handshake and HTTP/2 negotiation,
+// so the first API call of short-lived processes such as AWS Lambda functions doesn't pay for it. It sends an
+// unauthenticated HEAD request to the endpoint, bypassing middleware, statistics and auditing; any response
+// counts as success.
+func (s *Client) Warmup(ctx context.Context) error {
+	s.httpClient()
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, s.baseURL().String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.connTransport.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	return resp.Body.Close()
+}
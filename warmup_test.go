@@ -0,0 +1,82 @@
+package cloudhealth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// newHTTP2Server starts a TLS server supporting HTTP/2 and returns a Client trusting it, counting the
+// connections opened to it in conns.
+func newHTTP2Server(t *testing.T, handler http.HandlerFunc, conns *int32) (*httptest.Server, *Client) {
+	ts := httptest.NewUnstartedServer(handler)
+	ts.EnableHTTP2 = true
+	ts.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(conns, 1)
+		}
+	}
+	ts.StartTLS()
+
+	c, err := NewClient("apiKey", WithEndpoint(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient() returned an error: %s", err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(ts.Certificate())
+	transport := c.newHTTPTransport()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	c.baseTransport = transport
+	return ts, c
+}
+
+func TestHTTP2(t *testing.T) {
+	var conns int32
+	ts, c := newHTTP2Server(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor != 2 {
+			t.Errorf("Expected an HTTP/2 request, got %s", r.Proto)
+		}
+		w.Write([]byte(`{"generated_external_id":"externalid"}`))
+	}, &conns)
+	defer ts.Close()
+
+	if _, err := c.GetAwsExternalID(); err != nil {
+		t.Errorf("GetAwsExternalID() returned an error: %s", err)
+	}
+}
+
+func TestWarmup(t *testing.T) {
+	var conns int32
+	var methods []string
+	ts, c := newHTTP2Server(t, func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.Method == http.MethodHead && r.Header.Get("Authorization") != "" {
+			t.Errorf("Expected Warmup() not to send the API key")
+		}
+		w.Write([]byte(`{"generated_external_id":"externalid"}`))
+	}, &conns)
+	defer ts.Close()
+
+	if err := c.Warmup(context.Background()); err != nil {
+		t.Errorf("Warmup() returned an error: %s", err)
+		return
+	}
+	if conns != 1 {
+		t.Errorf("Warmup() expected to open a connection, got %d", conns)
+	}
+	if _, err := c.GetAwsExternalID(); err != nil {
+		t.Errorf("GetAwsExternalID() returned an error: %s", err)
+		return
+	}
+	if conns != 1 {
+		t.Errorf("Expected the request to reuse the warmed up connection, got %d connections", conns)
+	}
+	if len(methods) != 2 || methods[0] != http.MethodHead {
+		t.Errorf("Expected a HEAD request followed by the API call, got %v", methods)
+	}
+}